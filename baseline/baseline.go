@@ -0,0 +1,187 @@
+package baseline
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"junitparser/client"
+	"junitparser/configmap"
+	"junitparser/junit_parser/junit"
+	"junitparser/k8s"
+	"junitparser/result"
+)
+
+// configMapPrefix optionally marks a baseline source as a ConfigMap name,
+// as in "configmap/ocp-virt-validation-20250518-112311".
+const configMapPrefix = "configmap/"
+
+// Load reads a previous run to compare the current run with. The source is
+// either a results archive (.tar.gz or .tgz) as written by the checkup, a
+// result file in JSON or YAML, or the name of a results ConfigMap in the
+// results namespace. The results of an archive are read with the given
+// registry, like the ones of the current run; a result file or ConfigMap only
+// tells which tests failed.
+func Load(ctx context.Context, source string, registry *junit.Registry) (result.Baseline, error) {
+	if strings.HasPrefix(source, configMapPrefix) {
+		return loadConfigMap(ctx, strings.TrimPrefix(source, configMapPrefix))
+	}
+
+	info, err := os.Stat(source)
+	switch {
+	case err == nil && info.IsDir():
+		return result.Baseline{}, fmt.Errorf("baseline %s is a directory; expected a results archive or a result file", source)
+	case err == nil && isArchive(source):
+		return loadArchive(source, registry)
+	case err == nil:
+		return loadFile(source)
+	case !os.IsNotExist(err):
+		return result.Baseline{}, fmt.Errorf("failed to read baseline %s: %w", source, err)
+	case looksLikeFile(source):
+		return result.Baseline{}, fmt.Errorf("baseline file %q does not exist", source)
+	}
+
+	return loadConfigMap(ctx, source)
+}
+
+func loadConfigMap(ctx context.Context, name string) (result.Baseline, error) {
+	cm, err := k8s.GetCM(ctx, configmap.Namespace(), name)
+	if err != nil {
+		return result.Baseline{}, err
+	}
+
+	results, err := client.Decode(cm)
+	if err != nil {
+		return result.Baseline{}, fmt.Errorf("failed to read baseline configmap %s: %w", name, err)
+	}
+
+	// A truncated ConfigMap decodes to its archived full results, when they
+	// fitted in it; otherwise the failed tests left out are unknown.
+	return result.Baseline{
+		Source:    configMapPrefix + name,
+		Result:    results.Result,
+		Truncated: results.Result.IsTruncated(),
+	}, nil
+}
+
+func loadFile(fileName string) (result.Baseline, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return result.Baseline{}, fmt.Errorf("failed to read baseline file %s: %w", fileName, err)
+	}
+
+	res, err := result.Parse(data)
+	if err != nil {
+		return result.Baseline{}, fmt.Errorf("failed to read baseline file %s: %w", fileName, err)
+	}
+
+	return result.Baseline{Source: fileName, Result: res, Truncated: res.IsTruncated()}, nil
+}
+
+func loadArchive(fileName string, registry *junit.Registry) (result.Baseline, error) {
+	dir, err := os.MkdirTemp("", "baseline-")
+	if err != nil {
+		return result.Baseline{}, fmt.Errorf("failed to create a directory for the baseline archive: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := extractArchive(fileName, dir); err != nil {
+		return result.Baseline{}, fmt.Errorf("failed to extract baseline archive %s: %w", fileName, err)
+	}
+
+	junitRes, _, err := registry.ReadResults(dir)
+	if err != nil {
+		return result.Baseline{}, fmt.Errorf("failed to read baseline archive %s: %w", fileName, err)
+	}
+	if len(junitRes) == 0 {
+		return result.Baseline{}, fmt.Errorf("baseline archive %s holds no suite results", fileName)
+	}
+
+	return result.Baseline{Source: fileName, Result: result.New(junitRes), JUnitResults: junitRes}, nil
+}
+
+// extractArchive extracts the directories and regular files of a tar.gz
+// archive into dir. Nested archives, e.g. the one of a previous run that was
+// left in the results directory, are skipped.
+func extractArchive(fileName, dir string) error {
+	archive, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(header.Name)
+		if name == "." || isArchive(name) {
+			continue
+		}
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path %q in archive", header.Name)
+		}
+		target := filepath.Join(dir, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := extractFile(tarReader, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractFile(reader io.Reader, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, reader)
+	return err
+}
+
+func isArchive(fileName string) bool {
+	return strings.HasSuffix(fileName, ".tar.gz") || strings.HasSuffix(fileName, ".tgz")
+}
+
+// looksLikeFile reports whether a baseline source that does not exist was
+// meant as a file rather than as a ConfigMap name.
+func looksLikeFile(source string) bool {
+	if strings.Contains(source, "/") || isArchive(source) {
+		return true
+	}
+	switch filepath.Ext(source) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package baseline
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func writeArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	fileName := filepath.Join(t.TempDir(), "test-results-20261015.tar.gz")
+	archive, err := os.Create(fileName)
+	if err != nil {
+		t.Fatalf("failed to create archive: %v", err)
+	}
+	defer archive.Close()
+
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write archive header: %v", err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write archive content: %v", err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+
+	return fileName
+}
+
+func TestLoadArchive(t *testing.T) {
+	fileName := writeArchive(t, map[string]string{
+		"./compute/junit.results.xml":            `<testsuite tests="2" failures="1"><testcase name="a"><failure/></testcase><testcase name="b"/></testsuite>`,
+		"./compute/.exit_code":                   "1\n",
+		"./test-results-20261014.tar.gz":         "not extracted",
+		"./network/junit.results.xml":            `<testsuite tests="1"><testcase name="c"/></testsuite>`,
+		"./summary-log.txt":                      "Total Tests Run: 3\n",
+		"./tier2/artifacts/../junit.results.xml": `<testsuite tests="1"><testcase name="d"/></testsuite>`,
+	})
+
+	base, err := Load(context.Background(), fileName, junit.DefaultRegistry())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if base.Source != fileName {
+		t.Errorf("expected source %s, got %s", fileName, base.Source)
+	}
+	if base.JUnitResults == nil {
+		t.Fatal("expected the raw results of the archive to be known")
+	}
+	if len(base.Result.SigMap) != 3 || base.Result.Summary.Run != 4 || base.Result.Summary.Failed != 1 {
+		t.Errorf("unexpected baseline result: %+v", base.Result)
+	}
+}
+
+func TestLoadArchiveRejectsPathTraversal(t *testing.T) {
+	fileName := writeArchive(t, map[string]string{"../escape.txt": "x"})
+
+	if _, err := Load(context.Background(), fileName, junit.DefaultRegistry()); err == nil {
+		t.Error("expected an error for an archive entry outside of the extraction directory")
+	}
+}
+
+func TestLoadResultFile(t *testing.T) {
+	res := result.New(map[string]junit.TestSuite{
+		"compute": {
+			Tests:     2,
+			Failures:  1,
+			TestCases: []junit.TestCase{{Name: "a", Failure: &junit.Outcome{}}, {Name: "b"}},
+		},
+	})
+
+	for name, render := range map[string]result.Format{"result.json": result.FormatJSON, "result.yaml": result.FormatYAML} {
+		t.Run(name, func(t *testing.T) {
+			data, err := res.Render(render)
+			if err != nil {
+				t.Fatalf("failed to render result: %v", err)
+			}
+			fileName := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(fileName, data, 0644); err != nil {
+				t.Fatalf("failed to write result: %v", err)
+			}
+
+			base, err := Load(context.Background(), fileName, junit.DefaultRegistry())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if base.JUnitResults != nil {
+				t.Error("expected only the result of the baseline to be known")
+			}
+			if base.Result.SigMap["compute"].Failures != 1 || len(base.Result.SigMap["compute"].FailedTests[""]) != 1 {
+				t.Errorf("unexpected baseline result: %+v", base.Result)
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	for _, source := range []string{"/does/not/exist.json", "previous.tar.gz", "results.yaml"} {
+		if _, err := Load(context.Background(), source, junit.DefaultRegistry()); err == nil {
+			t.Errorf("expected an error for the missing file %s", source)
+		}
+	}
+}
+
+func TestLoadTruncatedResultFile(t *testing.T) {
+	res := result.New(map[string]junit.TestSuite{
+		"compute": {
+			Tests:     2,
+			Failures:  2,
+			TestCases: []junit.TestCase{{Name: "a", Failure: &junit.Outcome{}}, {Name: "b", Failure: &junit.Outcome{}}},
+		},
+	}).Truncate(1)
+
+	data, err := res.Render(result.FormatYAML)
+	if err != nil {
+		t.Fatalf("failed to render result: %v", err)
+	}
+	fileName := filepath.Join(t.TempDir(), "result.yaml")
+	if err := os.WriteFile(fileName, data, 0644); err != nil {
+		t.Fatalf("failed to write result: %v", err)
+	}
+
+	base, err := Load(context.Background(), fileName, junit.DefaultRegistry())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !base.Truncated {
+		t.Error("expected the baseline to be marked as truncated")
+	}
+}
@@ -0,0 +1,151 @@
+// Package client reads the results ConfigMaps of the checkup into typed
+// structs. It decodes every result format the checkup has written, including
+// the one of the ConfigMaps created before the format was versioned, so that
+// other tools do not have to guess the shape of the results.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"junitparser/configmap"
+	"junitparser/result"
+)
+
+// ErrUnsupportedAPIVersion is returned for results written in a format this
+// package does not know, e.g. by a newer checkup.
+var ErrUnsupportedAPIVersion = errors.New("unsupported result apiVersion")
+
+// Results are the results of a checkup run, as stored in its ConfigMap.
+type Results struct {
+	// Name is the name of the ConfigMap.
+	Name string
+	// StartTimestamp and CompletionTimestamp are the start and completion
+	// time of the run.
+	StartTimestamp      time.Time
+	CompletionTimestamp time.Time
+	// Result holds the results of the suites and their summary. Its
+	// APIVersion is set to result.APIVersion for the results written before
+	// the format was versioned, since theirs is the same format without the
+	// fields added since.
+	Result result.Result
+	// Comparison holds the differences with the baseline run, when the run
+	// was compared with one.
+	Comparison *result.Comparison
+	// Truncated is set when the results were too large for the ConfigMap
+	// and its lists of tests were truncated. Result then holds the full
+	// results when they were archived in the ConfigMap, and the truncated
+	// ones otherwise, with the number of tests left out under Omitted.
+	// FullResultsFile is the file holding the full results, relative to the
+	// results directory of the run on its PVC.
+	Truncated       bool
+	FullResultsFile string
+}
+
+// Get fetches and decodes the results ConfigMap name in namespace.
+func Get(ctx context.Context, cli kubernetes.Interface, namespace, name string) (Results, error) {
+	cm, err := cli.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return Results{}, fmt.Errorf("failed to get configmap %s/%s: %w", namespace, name, err)
+	}
+	return Decode(cm)
+}
+
+// List fetches and decodes the results ConfigMaps in namespace, oldest run
+// first. The ConfigMaps of the checkup that hold no results are skipped.
+func List(ctx context.Context, cli kubernetes.Interface, namespace string) ([]Results, error) {
+	cms, err := cli.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{LabelSelector: configmap.LabelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps in %s: %w", namespace, err)
+	}
+
+	var results []Results
+	for i := range cms.Items {
+		if _, ok := cms.Items[i].Data[configmap.ResultsKey]; !ok {
+			continue
+		}
+		res, err := Decode(&cms.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].StartTimestamp.Before(results[j].StartTimestamp)
+	})
+	return results, nil
+}
+
+// Decode decodes the results held by a results ConfigMap.
+func Decode(cm *corev1.ConfigMap) (Results, error) {
+	data, ok := cm.Data[configmap.ResultsKey]
+	if !ok {
+		return Results{}, fmt.Errorf("configmap %s has no %s key", cm.Name, configmap.ResultsKey)
+	}
+
+	full, archived, err := configmap.FullResults(cm)
+	if err != nil {
+		return Results{}, err
+	}
+	if archived {
+		data = string(full)
+	}
+
+	res, err := result.Parse([]byte(data))
+	if err != nil {
+		return Results{}, fmt.Errorf("failed to read configmap %s: %w", cm.Name, err)
+	}
+
+	switch res.APIVersion {
+	case "":
+		res.APIVersion = result.APIVersion
+	case result.APIVersion:
+	default:
+		return Results{}, fmt.Errorf("configmap %s: %w %q", cm.Name, ErrUnsupportedAPIVersion, res.APIVersion)
+	}
+
+	results := Results{Name: cm.Name, Result: res}
+	results.FullResultsFile = cm.Data[configmap.FullResultsFileKey]
+	results.Truncated = archived || results.FullResultsFile != "" || res.IsTruncated()
+
+	if results.StartTimestamp, err = parseTimestamp(cm, configmap.StartTimestampKey); err != nil {
+		return Results{}, err
+	}
+	if results.CompletionTimestamp, err = parseTimestamp(cm, configmap.CompletionTimestampKey); err != nil {
+		return Results{}, err
+	}
+
+	if data, ok := cm.Data[configmap.ComparisonKey]; ok {
+		var comparison result.Comparison
+		if err := yaml.Unmarshal([]byte(data), &comparison); err != nil {
+			return Results{}, fmt.Errorf("failed to read the %s key of configmap %s: %w", configmap.ComparisonKey, cm.Name, err)
+		}
+		results.Comparison = &comparison
+	}
+
+	return results, nil
+}
+
+// parseTimestamp parses the timestamp held by a data key of a results
+// ConfigMap. A missing key leaves the timestamp zero.
+func parseTimestamp(cm *corev1.ConfigMap, key string) (time.Time, error) {
+	value, ok := cm.Data[key]
+	if !ok || value == "" {
+		return time.Time{}, nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read the %s key of configmap %s: %w", key, cm.Name, err)
+	}
+	return timestamp, nil
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"junitparser/configmap"
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func newConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ocp-virt-validation-20261016-120000"},
+		Data:       data,
+	}
+}
+
+func TestDecodesCurrentConfigMap(t *testing.T) {
+	res := result.New(map[string]junit.TestSuite{
+		"tier2": {
+			Tests:    2,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "test_snapshot", Classname: "tests.storage.test_snapshot", Failure: &junit.Outcome{Message: "timed out"}},
+				{Name: "test_bridge", Classname: "tests.network.test_bridge"},
+			},
+		},
+	})
+	resYaml, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results, err := Decode(newConfigMap(map[string]string{
+		configmap.ResultsKey:             string(resYaml),
+		configmap.StartTimestampKey:      "2026-10-16T12:00:00Z",
+		configmap.CompletionTimestampKey: "2026-10-16T14:30:00Z",
+		configmap.ComparisonKey:          "baseline: configmap/ocp-virt-validation-20261015-120000\ntotal_deltas:\n  tests_failures: 1\n  tests_passed: -1\n  tests_run: 0\n  tests_skipped: 0\n",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results.Name != "ocp-virt-validation-20261016-120000" || results.Result.APIVersion != result.APIVersion {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if !results.StartTimestamp.Equal(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)) ||
+		results.CompletionTimestamp.Sub(results.StartTimestamp) != 150*time.Minute {
+		t.Errorf("unexpected timestamps %v and %v", results.StartTimestamp, results.CompletionTimestamp)
+	}
+
+	expectedFailedTests := result.FailedTestsMap{"storage": {"test_snapshot"}}
+	if failedTests := results.Result.SigMap["tier2"].FailedTests; !reflect.DeepEqual(failedTests, expectedFailedTests) {
+		t.Errorf("expected failed tests %v, got %v", expectedFailedTests, failedTests)
+	}
+	if results.Comparison == nil || results.Comparison.TotalDeltas.Failures != 1 {
+		t.Errorf("expected the comparison to be decoded, got %+v", results.Comparison)
+	}
+}
+
+func TestDecodesLegacyConfigMap(t *testing.T) {
+	// The results written before the format was versioned have no
+	// apiVersion and list the failed tests of Ginkgo suites flat.
+	results, err := Decode(newConfigMap(map[string]string{
+		configmap.ResultsKey: `compute:
+  failed_tests:
+  - '[sig-compute]VM should start'
+  tests_failures: 1
+  tests_passed: 9
+  tests_run: 10
+  tests_skipped: 2
+summary:
+  total_tests_failed: 1
+  total_tests_passed: 9
+  total_tests_run: 10
+  total_tests_skipped: 2
+`,
+		configmap.StartTimestampKey: "2025-05-18T11:23:11Z",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results.Result.APIVersion != result.APIVersion {
+		t.Errorf("expected apiVersion %s, got %q", result.APIVersion, results.Result.APIVersion)
+	}
+	if len(results.Result.SigMap) != 1 || results.Result.Summary.Run != 10 {
+		t.Errorf("unexpected result: %+v", results.Result)
+	}
+	expectedFailedTests := result.FailedTestsMap{"": {"[sig-compute]VM should start"}}
+	if failedTests := results.Result.SigMap["compute"].FailedTests; !reflect.DeepEqual(failedTests, expectedFailedTests) {
+		t.Errorf("expected failed tests %v, got %v", expectedFailedTests, failedTests)
+	}
+	if !results.CompletionTimestamp.IsZero() || results.Comparison != nil {
+		t.Errorf("expected no completion timestamp and no comparison, got %+v", results)
+	}
+}
+
+func TestDecodesTruncatedConfigMap(t *testing.T) {
+	var testCases []junit.TestCase
+	for i := 0; i < 3; i++ {
+		testCases = append(testCases, junit.TestCase{Name: fmt.Sprintf("test_%d", i), Failure: &junit.Outcome{}})
+	}
+	res := result.New(map[string]junit.TestSuite{"compute": {Tests: 3, Failures: 3, TestCases: testCases}})
+
+	full, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	truncated, err := res.Truncate(1).GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var archive bytes.Buffer
+	writer := gzip.NewWriter(&archive)
+	writer.Write(full)
+	writer.Close()
+
+	cm := newConfigMap(map[string]string{
+		configmap.ResultsKey:         string(truncated),
+		configmap.FullResultsFileKey: "self-validation-results.yaml",
+	})
+	cm.BinaryData = map[string][]byte{configmap.FullResultsKey: archive.Bytes()}
+
+	results, err := Decode(cm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !results.Truncated || results.FullResultsFile != "self-validation-results.yaml" {
+		t.Errorf("expected the results to be reported as truncated, got %+v", results)
+	}
+	if failedTests := results.Result.SigMap["compute"].FailedTests[""]; len(failedTests) != 3 {
+		t.Errorf("expected the full results to be decoded from the archive, got %v", failedTests)
+	}
+
+	cm.BinaryData = nil
+	delete(cm.Data, configmap.FullResultsFileKey)
+	results, err = Decode(cm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if compute := results.Result.SigMap["compute"]; !results.Truncated || compute.Omitted["failed_tests"] != 2 {
+		t.Errorf("expected the truncated results with their omitted count, got %+v", compute)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]string
+	}{
+		{name: "missing results", data: map[string]string{configmap.StartTimestampKey: "2026-10-16T12:00:00Z"}},
+		{name: "invalid results", data: map[string]string{configmap.ResultsKey: "compute: [1, 2"}},
+		{name: "invalid timestamp", data: map[string]string{configmap.ResultsKey: "summary: {}", configmap.StartTimestampKey: "20261016-120000"}},
+		{name: "invalid comparison", data: map[string]string{configmap.ResultsKey: "summary: {}", configmap.ComparisonKey: "suites: [1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(newConfigMap(tt.data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	_, err := Decode(newConfigMap(map[string]string{configmap.ResultsKey: "apiVersion: ocp-virt-validation/v2\nsummary: {}"}))
+	if !errors.Is(err, ErrUnsupportedAPIVersion) {
+		t.Errorf("expected an unsupported apiVersion error, got %v", err)
+	}
+}
@@ -4,12 +4,33 @@ import (
 	"errors"
 	"flag"
 	"sync"
+
+	"junitparser/result"
 )
 
 type Config struct {
 	ResultsDir          string
 	StartTimestamp      string
 	CompletionTimestamp string
+	ResultSources       string
+	Crit                string
+	Level               string
+	ExpectedSuites      string
+	Output              string
+	OutputFile          string
+	HTMLReport          string
+	Baseline            string
+	Policy              string
+	KnownIssues         string
+	SkipLists           string
+	SkipListSuites      string
+	Template            string
+	JUnitExport         string
+	PolarionExport      string
+	PolarionProject     string
+	PolarionIDPrefix    string
+	CNVVersion          string
+	ScoreWeights        string
 }
 
 var (
@@ -22,6 +43,25 @@ func GetConfig() Config {
 		flag.StringVar(&cfg.ResultsDir, "results-dir", "", "Directory to read the result files from")
 		flag.StringVar(&cfg.StartTimestamp, "start-timestamp", "", "test start timestamp")
 		flag.StringVar(&cfg.CompletionTimestamp, "completion-timestamp", "", "test completion timestamp")
+		flag.StringVar(&cfg.ResultSources, "result-sources", "", "JSON file with additional result sources per suite")
+		flag.StringVar(&cfg.Crit, "crit", "", "Comma-separated criticalities (e.g. high,critical) to restrict the summary to")
+		flag.StringVar(&cfg.Level, "level", "", "Comma-separated levels (e.g. system) to restrict the summary to")
+		flag.StringVar(&cfg.ExpectedSuites, "expected-suites", "", "Comma-separated suites that were supposed to run, to report the ones that never started")
+		flag.StringVar(&cfg.Output, "output", string(result.FormatText), "Output format of the results: text, json, yaml or markdown")
+		flag.StringVar(&cfg.OutputFile, "output-file", "", "File to write the results to in the output format; the text summary is still printed to stdout")
+		flag.StringVar(&cfg.HTMLReport, "html-report", "report.html", "HTML report to write, relative to the results directory; empty to disable")
+		flag.StringVar(&cfg.Baseline, "baseline", "", "Previous run to compare the results with: a results ConfigMap name, a JSON or YAML result file, or a results archive")
+		flag.StringVar(&cfg.Policy, "policy", "", "JSON or YAML policy file to judge the results with; the verdict sets the exit code")
+		flag.StringVar(&cfg.KnownIssues, "known-issues", "", "Comma-separated JSON or YAML known-issues catalogs to flag known failures with")
+		flag.StringVar(&cfg.SkipLists, "skip-lists", "", "Comma-separated JSON or YAML skip lists the tests were excluded from the run with, to tell their skipped tests apart")
+		flag.StringVar(&cfg.SkipListSuites, "skip-list-suites", "compute,network,storage", "Comma-separated suites the skip lists were applied to")
+		flag.StringVar(&cfg.Template, "template", "", "Go text/template file to render the text summary with, instead of the built-in layout")
+		flag.StringVar(&cfg.JUnitExport, "junit-export", "", "Merged JUnit XML of all suites to write, relative to the results directory; empty to disable")
+		flag.StringVar(&cfg.PolarionExport, "polarion-export", "", "Polarion XUnit importer XML of all suites to write, relative to the results directory; empty to disable")
+		flag.StringVar(&cfg.PolarionProject, "polarion-project", "", "Polarion project ID to import the results into")
+		flag.StringVar(&cfg.PolarionIDPrefix, "polarion-id-prefix", "CNV-", "Prefix of the Polarion test-case IDs, prepended to the test_id tags")
+		flag.StringVar(&cfg.CNVVersion, "cnv-version", "", "Version of OpenShift Virtualization the tests ran against, recorded in the XML exports")
+		flag.StringVar(&cfg.ScoreWeights, "score-weights", "", "JSON or YAML file with the crit and level weights of the readiness score, instead of the default ones")
 		flag.Parse()
 	})
 	return cfg
@@ -37,6 +77,9 @@ func (c Config) Validate() error {
 	if c.CompletionTimestamp == "" {
 		return errors.New("completion-timestamp flag is required")
 	}
+	if _, err := result.ParseFormat(c.Output); err != nil {
+		return err
+	}
 
 	return nil
 }
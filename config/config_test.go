@@ -25,6 +25,9 @@ func TestGetConfig(t *testing.T) {
 	if cfg.CompletionTimestamp != "2023-01-01T01:00:00Z" {
 		t.Errorf("expected CompletionTimestamp to be '2023-01-01T01:00:00Z', got '%s'", cfg.CompletionTimestamp)
 	}
+	if cfg.Output != "text" {
+		t.Errorf("expected Output to default to 'text', got '%s'", cfg.Output)
+	}
 }
 
 func TestValidate(t *testing.T) {
@@ -58,6 +61,16 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "unknown Output",
+			config: Config{
+				ResultsDir:          "/tmp/results",
+				StartTimestamp:      "2023-01-01T00:00:00Z",
+				CompletionTimestamp: "2023-01-01T01:00:00Z",
+				Output:              "html",
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing CompletionTimestamp",
 			config: Config{
@@ -16,14 +16,33 @@ import (
 const (
 	appName      = "ocp-virt-validation"
 	cmNamePrefix = appName + "-"
+
+	// ResultsKey is the data key holding the results summary in YAML.
+	ResultsKey = "self-validation-results"
+	// ComparisonKey is the data key holding the comparison with a baseline
+	// run in YAML, when a baseline was configured.
+	ComparisonKey = "baseline-comparison"
+	// StartTimestampKey and CompletionTimestampKey are the data keys holding
+	// the start and completion time of the run, in RFC 3339 format.
+	StartTimestampKey      = "status.startTimestamp"
+	CompletionTimestampKey = "status.completionTimestamp"
+
+	// LabelSelector selects the results ConfigMaps of the checkup.
+	LabelSelector = "app=" + appName
 )
 
-func New(cfg config.Config, result []byte) (*corev1.ConfigMap, error) {
+// Namespace returns the namespace the results ConfigMaps are created in.
+func Namespace() string {
 	// Get namespace from environment variable with default fallback
 	namespace := os.Getenv("CONFIGMAP_NAMESPACE")
 	if namespace == "" {
 		namespace = appName
 	}
+	return namespace
+}
+
+func New(cfg config.Config, result []byte) (*corev1.ConfigMap, error) {
+	namespace := Namespace()
 
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -33,9 +52,9 @@ func New(cfg config.Config, result []byte) (*corev1.ConfigMap, error) {
 			},
 		},
 		Data: map[string]string{
-			"self-validation-results":    string(result),
-			"status.startTimestamp":      cfg.StartTimestamp,
-			"status.completionTimestamp": cfg.CompletionTimestamp,
+			ResultsKey:             string(result),
+			StartTimestampKey:      cfg.StartTimestamp,
+			CompletionTimestampKey: cfg.CompletionTimestamp,
 		},
 	}
 
@@ -0,0 +1,145 @@
+package configmap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"junitparser/result"
+)
+
+const (
+	// MaxDataSize is the limit the API server validates the total size of
+	// the data of a ConfigMap against.
+	MaxDataSize = 1024 * 1024
+
+	// FullResultsKey is the binaryData key holding the gzipped full results
+	// in YAML, when the ResultsKey ones had to be truncated.
+	FullResultsKey = ResultsKey + ".yaml.gz"
+	// FullResultsFileKey is the data key holding the path of the file with
+	// the full results, relative to the results directory on the PVC, when
+	// the ResultsKey ones had to be truncated.
+	FullResultsFileKey = "full_results_file"
+
+	// maxListLength is the length the lists of tests are first truncated
+	// to; it is halved until the results fit.
+	maxListLength = 1024
+)
+
+// DataSize returns the total size of the data of cm, as the API server
+// measures it.
+func DataSize(cm *corev1.ConfigMap) int {
+	size := 0
+	for key, value := range cm.Data {
+		size += len(key) + len(value)
+	}
+	for key, value := range cm.BinaryData {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// FitResults keeps the data of cm under MaxDataSize, so that the results
+// of a run with a lot of failed tests can still be stored. When they do not
+// fit, the lists of res and of its comparison with the baseline are
+// truncated, with the number of entries left out recorded in the results,
+// and fullResultsFile is recorded as the file holding the full results,
+// which the caller is expected to write. The full results are also archived
+// under FullResultsKey, unless the archive does not fit either. When even
+// the results without their lists do not fit, only the counts of
+// Result.Minimal are stored. It returns whether the results were truncated,
+// and an error when the results cannot be made to fit at all.
+func FitResults(cm *corev1.ConfigMap, res result.Result, fullResultsFile string) (bool, error) {
+	if DataSize(cm) <= MaxDataSize {
+		return false, nil
+	}
+
+	archive, err := gzipData([]byte(cm.Data[ResultsKey]))
+	if err != nil {
+		return false, fmt.Errorf("failed to archive the full results; %w", err)
+	}
+	cm.Data[FullResultsFileKey] = fullResultsFile
+
+	for limit := maxListLength; ; limit /= 2 {
+		if err := setResults(cm, res.Truncate(limit)); err != nil {
+			return false, err
+		}
+
+		// The archive is dropped before the lists are truncated further, as
+		// the full results are on the PVC anyway.
+		cm.BinaryData = map[string][]byte{FullResultsKey: archive}
+		if DataSize(cm) <= MaxDataSize {
+			return true, nil
+		}
+		cm.BinaryData = nil
+		if DataSize(cm) <= MaxDataSize {
+			return true, nil
+		}
+
+		if limit == 0 {
+			break
+		}
+	}
+
+	if err := setResults(cm, res.Minimal()); err != nil {
+		return false, err
+	}
+	if DataSize(cm) <= MaxDataSize {
+		return true, nil
+	}
+	return true, fmt.Errorf("the results do not fit in a configmap even with only their counts; %d bytes", DataSize(cm))
+}
+
+// setResults stores res, and its comparison with the baseline if any, in cm.
+func setResults(cm *corev1.ConfigMap, res result.Result) error {
+	resYaml, err := res.GetYaml()
+	if err != nil {
+		return err
+	}
+	cm.Data[ResultsKey] = string(resYaml)
+
+	if res.Comparison != nil {
+		comparisonYaml, err := res.Comparison.GetYaml()
+		if err != nil {
+			return err
+		}
+		cm.Data[ComparisonKey] = string(comparisonYaml)
+	}
+	return nil
+}
+
+// FullResults returns the full results archived in cm when its results were
+// truncated, and whether there were any.
+func FullResults(cm *corev1.ConfigMap) ([]byte, bool, error) {
+	archive, ok := cm.BinaryData[FullResultsKey]
+	if !ok {
+		return nil, false, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read the %s key of configmap %s: %w", FullResultsKey, cm.Name, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read the %s key of configmap %s: %w", FullResultsKey, cm.Name, err)
+	}
+	return data, true, nil
+}
+
+func gzipData(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
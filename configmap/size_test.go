@@ -0,0 +1,162 @@
+package configmap
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"junitparser/config"
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+// largeResult returns a result whose failed tests take about size bytes.
+func largeResult(size int) result.Result {
+	var testCases []junit.TestCase
+	for i := 0; i*200 < size; i++ {
+		testCases = append(testCases, junit.TestCase{
+			Name:    fmt.Sprintf("[sig-compute]VM Live Migration %s [test_id:%d]should be migrated", strings.Repeat("with a very long name ", 8), i),
+			Failure: &junit.Outcome{Message: fmt.Sprintf("Timed out after %ds", i)},
+		})
+	}
+	return result.New(map[string]junit.TestSuite{
+		"compute": {Tests: len(testCases), Failures: len(testCases), TestCases: testCases},
+	})
+}
+
+func newResultsConfigMap(t *testing.T, res result.Result) *corev1.ConfigMap {
+	t.Helper()
+	resYaml, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Setenv("TIMESTAMP", "20261016-120000")
+	cm, err := New(config.Config{StartTimestamp: "2026-10-16T12:00:00Z"}, resYaml)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Comparison != nil {
+		comparisonYaml, err := res.Comparison.GetYaml()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cm.Data[ComparisonKey] = string(comparisonYaml)
+	}
+	return cm
+}
+
+func TestFitResultsKeepsSmallResults(t *testing.T) {
+	cm := newResultsConfigMap(t, largeResult(10*1024))
+	results := cm.Data[ResultsKey]
+
+	truncated, err := FitResults(cm, largeResult(10*1024), "self-validation-results.yaml")
+	if err != nil || truncated {
+		t.Fatalf("expected the results to fit, got %v and %v", truncated, err)
+	}
+	if cm.Data[ResultsKey] != results || cm.BinaryData != nil {
+		t.Error("expected the configmap to be left untouched")
+	}
+	if _, ok := cm.Data[FullResultsFileKey]; ok {
+		t.Error("expected no full results file")
+	}
+}
+
+func TestFitResultsTruncatesLargeResults(t *testing.T) {
+	res := largeResult(2 * MaxDataSize)
+	cm := newResultsConfigMap(t, res)
+	full := cm.Data[ResultsKey]
+
+	truncated, err := FitResults(cm, res, "self-validation-results.yaml")
+	if err != nil || !truncated {
+		t.Fatalf("expected the results to be truncated, got %v and %v", truncated, err)
+	}
+	if size := DataSize(cm); size > MaxDataSize {
+		t.Errorf("expected the data to fit in %d bytes, got %d", MaxDataSize, size)
+	}
+	if cm.Data[FullResultsFileKey] != "self-validation-results.yaml" {
+		t.Errorf("expected the full results file to be recorded, got %q", cm.Data[FullResultsFileKey])
+	}
+
+	fitted, err := result.Parse([]byte(cm.Data[ResultsKey]))
+	if err != nil {
+		t.Fatalf("failed to parse the truncated results: %v", err)
+	}
+	compute := fitted.SigMap["compute"]
+	if kept := len(compute.FailedTests[""]); kept == 0 || kept+compute.Omitted["failed_tests"] != compute.Failures {
+		t.Errorf("expected the failed tests to be truncated with an omitted count, got %d kept and %v", kept, compute.Omitted)
+	}
+
+	archived, ok, err := FullResults(cm)
+	if err != nil || !ok {
+		t.Fatalf("expected the full results to be archived, got %v and %v", ok, err)
+	}
+	if string(archived) != full {
+		t.Error("expected the archive to hold the full results")
+	}
+}
+
+func TestFitResultsTruncatesLargeComparison(t *testing.T) {
+	res := largeResult(10 * 1024)
+	var newlyFailing []string
+	for i := 0; i*200 < 2*MaxDataSize; i++ {
+		newlyFailing = append(newlyFailing, fmt.Sprintf("[sig-compute]VM Live Migration %s [test_id:%d]should be migrated", strings.Repeat("with a very long name ", 8), i))
+	}
+	res.Comparison = &result.Comparison{
+		Baseline: "previous",
+		Suites: map[string]result.SuiteComparison{
+			"compute": {Deltas: result.TestCounts{Failures: len(newlyFailing)}, NewlyFailing: newlyFailing},
+		},
+	}
+	cm := newResultsConfigMap(t, res)
+
+	truncated, err := FitResults(cm, res, "self-validation-results.yaml")
+	if err != nil || !truncated {
+		t.Fatalf("expected the results to be truncated, got %v and %v", truncated, err)
+	}
+	if size := DataSize(cm); size > MaxDataSize {
+		t.Errorf("expected the data to fit in %d bytes, got %d", MaxDataSize, size)
+	}
+
+	var comparison result.Comparison
+	if err := yaml.Unmarshal([]byte(cm.Data[ComparisonKey]), &comparison); err != nil {
+		t.Fatalf("failed to parse the truncated comparison: %v", err)
+	}
+	compute := comparison.Suites["compute"]
+	if kept := len(compute.NewlyFailing); kept == 0 || kept+compute.Omitted["newly_failing"] != len(newlyFailing) {
+		t.Errorf("expected the newly failing tests to be truncated with an omitted count, got %d kept and %v", kept, compute.Omitted)
+	}
+	if compute.Deltas.Failures != len(newlyFailing) {
+		t.Errorf("expected the deltas to be kept, got %+v", compute.Deltas)
+	}
+}
+
+func TestFitResultsKeepsOnlyCountsWhenListsDoNotFit(t *testing.T) {
+	res := largeResult(10 * 1024)
+	res.Summary.FailureSignatures = []result.FailureSignature{{Signature: strings.Repeat("x", 2*MaxDataSize), Count: 1}}
+	cm := newResultsConfigMap(t, res)
+
+	truncated, err := FitResults(cm, res, "self-validation-results.yaml")
+	if err != nil || !truncated {
+		t.Fatalf("expected the results to be truncated, got %v and %v", truncated, err)
+	}
+	fitted, err := result.Parse([]byte(cm.Data[ResultsKey]))
+	if err != nil {
+		t.Fatalf("failed to parse the minimal results: %v", err)
+	}
+	if compute := fitted.SigMap["compute"]; compute.Failures != res.SigMap["compute"].Failures || compute.FailedTests != nil || fitted.Summary.FailureSignatures != nil {
+		t.Errorf("expected only the counts to be kept, got %+v", fitted)
+	}
+}
+
+func TestFitResultsFailsWhenCountsDoNotFit(t *testing.T) {
+	res := largeResult(10 * 1024)
+	res.Summary.VerdictReasons = []string{strings.Repeat("x", 2*MaxDataSize)}
+	cm := newResultsConfigMap(t, res)
+
+	if _, err := FitResults(cm, res, "self-validation-results.yaml"); err == nil {
+		t.Error("expected an error when even the counts do not fit")
+	}
+}
@@ -1,12 +1,11 @@
 package junit
 
 import (
-	"encoding/xml"
-	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -14,7 +13,25 @@ import (
 
 const junitFileName = "junit.results.xml"
 
+// NewResultMap reads the results of the suites in dir using the default
+// registry. Unknown directories are reported on stderr.
 func NewResultMap(dir string) (map[string]TestSuite, error) {
+	junitResults, unknown, err := DefaultRegistry().ReadResults(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range unknown {
+		fmt.Fprintf(os.Stderr, "no result source registered for directory %q; skipping\n", name)
+	}
+
+	return junitResults, nil
+}
+
+// ReadResults reads the results of every registered suite directory in dir.
+// The names of directories that are neither registered nor ignored are
+// returned, sorted, as unknown.
+func (r *Registry) ReadResults(dir string) (map[string]TestSuite, []string, error) {
 	type resultWithSig struct {
 		sig         string
 		junitResult TestSuite
@@ -22,25 +39,22 @@ func NewResultMap(dir string) (map[string]TestSuite, error) {
 
 	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	wg := &sync.WaitGroup{}
 
 	ch := make(chan resultWithSig, 1)
 
+	var unknown []string
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		if !entry.IsDir() || r.isIgnored(entry.Name()) {
 			continue
 		}
 
-		// Skip the lost+found directory
-		if entry.Name() == "lost+found" {
-			continue
-		}
-
-		// Skip the .dry-run directory (used by progress_watcher for test discovery)
-		if entry.Name() == ".dry-run" {
+		sources, ok := r.Suites[entry.Name()]
+		if !ok {
+			unknown = append(unknown, entry.Name())
 			continue
 		}
 
@@ -48,21 +62,31 @@ func NewResultMap(dir string) (map[string]TestSuite, error) {
 
 		go func(sig string) {
 			defer wg.Done()
-			exitCode := readExitCode(path.Join(dir, sig, ".exit_code"))
+			exitCode, hasExitCode := readExitCode(path.Join(dir, sig, ".exit_code"))
+			markers := readLogMarkers(path.Join(dir, sig, sig+suiteLogSuffix))
+			phases, err := ReadPhases(path.Join(dir, sig, PhasesFileName))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "WARNING: failed to read the phases of suite %q; %v\n", sig, err)
+			}
 
-			fileName := path.Join(dir, sig, junitFileName)
-			junitResult, err := readOneFile(fileName)
+			junitResult, err := readSuiteDir(path.Join(dir, sig), sources)
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				if exitCode != 0 {
-					ch <- resultWithSig{sig: sig, junitResult: TestSuite{SetupFailure: true}}
+				switch status := deriveStatus(nil, exitCode, hasExitCode, markers); status {
+				case StatusNotStarted:
+				case StatusNoTestsSelected:
+					ch <- resultWithSig{sig: sig, junitResult: TestSuite{Status: status, Phases: phases}}
+				default:
+					ch <- resultWithSig{sig: sig, junitResult: TestSuite{SetupFailure: true, Status: status, Phases: phases}}
 				}
 				return
 			}
 
-			if exitCode != 0 && junitResult.Failures == 0 && junitResult.Errors == 0 {
+			if exitCode != 0 && !junitResult.Incomplete && junitResult.Failures == 0 && junitResult.Errors == 0 {
 				junitResult.SetupFailure = true
 			}
+			junitResult.Status = deriveStatus(&junitResult, exitCode, hasExitCode, markers)
+			junitResult.Phases = phases
 
 			ch <- resultWithSig{sig: sig, junitResult: junitResult}
 		}(entry.Name())
@@ -78,19 +102,24 @@ func NewResultMap(dir string) (map[string]TestSuite, error) {
 		junitResults[res.sig] = res.junitResult
 	}
 
-	return junitResults, nil
+	sort.Strings(unknown)
+
+	return junitResults, unknown, nil
 }
 
-func readExitCode(filePath string) int {
+// readExitCode returns the exit code recorded by the runner script, and
+// whether one was recorded at all. The SSP runner and interrupted runs may
+// leave no exit code behind.
+func readExitCode(filePath string) (int, bool) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return 0
+		return 0, false
 	}
 	code, err := strconv.Atoi(strings.TrimSpace(string(data)))
 	if err != nil {
-		return 0
+		return 0, false
 	}
-	return code
+	return code, true
 }
 
 func readOneFile(fileName string) (TestSuite, error) {
@@ -112,30 +141,23 @@ func readOneFile(fileName string) (TestSuite, error) {
 	return testSuite, nil
 }
 
+// readTestSuite reads a JUnit document and merges all of its <testsuite>
+// elements into a single TestSuite. The duration of the merged suite is the
+// one of the document rather than the sum of its suites, as those may have
+// run in parallel, e.g. on several pytest-xdist workers.
 func readTestSuite(reader io.Reader) (TestSuite, error) {
-	testSuites := TestSuites{}
-	xmlContent, err := io.ReadAll(reader)
+	testSuites, documentTime, err := decodeTestSuites(reader)
 	if err != nil {
 		return TestSuite{}, err
 	}
 
-	err = xml.Unmarshal(xmlContent, &testSuites)
-	if err != nil {
-		var expErr xml.UnmarshalError
-		if errors.As(err, &expErr) {
-			testSuite := TestSuite{}
-			err = xml.Unmarshal(xmlContent, &testSuite)
-			if err != nil {
-				return TestSuite{}, err
-			}
-			return testSuite, nil
-		}
-		return TestSuite{}, err
+	for i := range testSuites {
+		collapseRetries(&testSuites[i])
 	}
 
-	if len(testSuites.TestSuites) == 0 {
-		return TestSuite{}, nil
+	merged := mergeTestSuites(testSuites)
+	if documentTime > 0 {
+		merged.Time = documentTime
 	}
-
-	return testSuites.TestSuites[0], nil
+	return merged, nil
 }
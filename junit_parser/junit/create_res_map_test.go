@@ -3,6 +3,7 @@ package junit
 import (
 	"os"
 	"path"
+	"strings"
 	"testing"
 )
 
@@ -31,8 +32,8 @@ func generateResDir(t *testing.T, files map[string]string) string {
 
 func TestNewResultMapWithValidFiles(t *testing.T) {
 	dir := generateResDir(t, map[string]string{
-		"sig1": `<testsuite name="suite1"></testsuite>`,
-		"sig2": `<testsuite name="suite2"></testsuite>`,
+		"compute": `<testsuite name="suite1"></testsuite>`,
+		"network": `<testsuite name="suite2"></testsuite>`,
 	})
 
 	result, err := NewResultMap(dir)
@@ -44,12 +45,12 @@ func TestNewResultMapWithValidFiles(t *testing.T) {
 		t.Errorf("expected 2 results, got %d", len(result))
 	}
 
-	if _, ok := result["sig1"]; !ok {
-		t.Errorf("expected result for sig1")
+	if _, ok := result["compute"]; !ok {
+		t.Errorf("expected result for compute")
 	}
 
-	if _, ok := result["sig2"]; !ok {
-		t.Errorf("expected result for sig2")
+	if _, ok := result["network"]; !ok {
+		t.Errorf("expected result for network")
 	}
 }
 
@@ -67,7 +68,7 @@ func TestNewResultMapWithEmptyDirectory(t *testing.T) {
 }
 
 func TestNewResultMapWithMissingJunitFiles(t *testing.T) {
-	dir := generateResDir(t, map[string]string{"sig1": "", "sig2": ""})
+	dir := generateResDir(t, map[string]string{"compute": "", "network": ""})
 
 	result, err := NewResultMap(dir)
 	if err != nil {
@@ -81,8 +82,8 @@ func TestNewResultMapWithMissingJunitFiles(t *testing.T) {
 
 func TestNewResultMapWithInvalidJunitFiles(t *testing.T) {
 	dir := generateResDir(t, map[string]string{
-		"sig1": `<invalid>`,
-		"sig2": `<invalid>`,
+		"compute": `<invalid>`,
+		"network": `<invalid>`,
 	})
 
 	result, err := NewResultMap(dir)
@@ -102,8 +103,8 @@ func TestNewResultMapSkipsLostAndFoundDirectory(t *testing.T) {
 </testsuite>`
 
 	dir := generateResDir(t, map[string]string{
-		"sig1": validJunitContent,
-		"sig2": validJunitContent,
+		"compute": validJunitContent,
+		"network": validJunitContent,
 	})
 
 	// Create a lost+found directory (without junit file)
@@ -118,7 +119,7 @@ func TestNewResultMapSkipsLostAndFoundDirectory(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Should only have 2 results (sig1 and sig2), lost+found should be skipped
+	// Should only have 2 results (compute and network), lost+found should be skipped
 	if len(result) != 2 {
 		t.Errorf("expected 2 results, got %d", len(result))
 	}
@@ -129,10 +130,149 @@ func TestNewResultMapSkipsLostAndFoundDirectory(t *testing.T) {
 	}
 
 	// Verify that the valid directories are present
-	if _, exists := result["sig1"]; !exists {
-		t.Error("sig1 should be present in results but was not found")
+	if _, exists := result["compute"]; !exists {
+		t.Error("compute should be present in results but was not found")
 	}
-	if _, exists := result["sig2"]; !exists {
-		t.Error("sig2 should be present in results but was not found")
+	if _, exists := result["network"]; !exists {
+		t.Error("network should be present in results but was not found")
+	}
+}
+
+func TestReadOneFileMergesPytestSuites(t *testing.T) {
+	testSuite, err := readOneFile(path.Join("testdata", "multi-suite", "junit.results-xdist.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Tests != 7 {
+		t.Errorf("expected 7 tests, got %d", testSuite.Tests)
+	}
+	if testSuite.Failures != 1 {
+		t.Errorf("expected 1 failure, got %d", testSuite.Failures)
+	}
+	if testSuite.Errors != 1 {
+		t.Errorf("expected 1 error, got %d", testSuite.Errors)
+	}
+	if testSuite.Skipped != 1 {
+		t.Errorf("expected 1 skipped, got %d", testSuite.Skipped)
+	}
+	// The xdist workers ran in parallel, so the suite took as long as the
+	// slowest one.
+	if testSuite.Time != 120.5 {
+		t.Errorf("expected time 120.5, got %v", testSuite.Time)
+	}
+	if len(testSuite.TestCases) != 7 {
+		t.Errorf("expected 7 test cases, got %d", len(testSuite.TestCases))
+	}
+	if len(testSuite.SubSuites) != 2 {
+		t.Fatalf("expected 2 sub-suites, got %d", len(testSuite.SubSuites))
+	}
+	if testSuite.SubSuites[1].Tests != 3 || len(testSuite.SubSuites[1].TestCases) != 3 {
+		t.Errorf("expected second sub-suite to keep its own counts, got %+v", testSuite.SubSuites[1])
+	}
+}
+
+func TestReadOneFileMergesGinkgoSuites(t *testing.T) {
+	testSuite, err := readOneFile(path.Join("testdata", "multi-suite", "junit.results-ginkgo.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Name != "Tests Suite (node 1)" {
+		t.Errorf("expected merged suite to keep the first suite name, got '%s'", testSuite.Name)
+	}
+	if testSuite.Tests != 4 {
+		t.Errorf("expected 4 tests, got %d", testSuite.Tests)
+	}
+	if testSuite.Failures != 1 {
+		t.Errorf("expected 1 failure, got %d", testSuite.Failures)
+	}
+	if len(testSuite.TestCases) != 5 {
+		t.Errorf("expected 5 test cases, got %d", len(testSuite.TestCases))
+	}
+
+	names := []string{testSuite.SubSuites[0].Name, testSuite.SubSuites[1].Name}
+	if names[0] != "Tests Suite (node 1)" || names[1] != "Tests Suite (node 2)" {
+		t.Errorf("unexpected sub-suite names: %v", names)
+	}
+}
+
+func TestReadOneFileWithSingleSuiteHasNoSubSuites(t *testing.T) {
+	testSuite, err := readOneFile(path.Join("testdata", "results", "ssp", "junit.results-real.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Tests != 40 {
+		t.Errorf("expected 40 tests, got %d", testSuite.Tests)
+	}
+	if len(testSuite.SubSuites) != 0 {
+		t.Errorf("expected no sub-suites for a single-suite file, got %d", len(testSuite.SubSuites))
+	}
+}
+
+func TestReadTestSuiteCollapsesRetriedEntries(t *testing.T) {
+	data := `<testsuite name="Tests Suite" tests="5" failures="3" errors="0">
+  <testcase name="flaky" classname="Tests Suite"><failure message="attempt 1"/></testcase>
+  <testcase name="flaky" classname="Tests Suite"><failure message="attempt 2"/></testcase>
+  <testcase name="flaky" classname="Tests Suite"></testcase>
+  <testcase name="broken" classname="Tests Suite"><failure message="always fails"/></testcase>
+  <testcase name="stable" classname="Tests Suite"></testcase>
+</testsuite>`
+
+	testSuite, err := readTestSuite(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Tests != 3 {
+		t.Errorf("expected 3 tests after collapsing retries, got %d", testSuite.Tests)
+	}
+	if testSuite.Failures != 1 {
+		t.Errorf("expected 1 failure after collapsing retries, got %d", testSuite.Failures)
+	}
+	if len(testSuite.TestCases) != 3 {
+		t.Fatalf("expected 3 test cases, got %d", len(testSuite.TestCases))
+	}
+
+	flaky := testSuite.TestCases[0]
+	if flaky.Name != "flaky" || flaky.Attempts != 3 || !flaky.IsFlaky() {
+		t.Errorf("expected 'flaky' to pass on its 3rd attempt, got %+v", flaky)
+	}
+	if testSuite.TestCases[1].IsFlaky() || testSuite.TestCases[2].IsFlaky() {
+		t.Error("expected 'broken' and 'stable' not to be flaky")
+	}
+}
+
+func TestReadTestSuiteKeepsPassingDuplicates(t *testing.T) {
+	data := `<testsuite name="Tests Suite" tests="2" failures="0">
+  <testcase name="same name" classname="Tests Suite"></testcase>
+  <testcase name="same name" classname="Tests Suite"></testcase>
+</testsuite>`
+
+	testSuite, err := readTestSuite(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Tests != 2 || len(testSuite.TestCases) != 2 {
+		t.Errorf("expected passing duplicates to be kept, got tests=%d cases=%d", testSuite.Tests, len(testSuite.TestCases))
+	}
+}
+
+func TestReadTestSuiteCollapsesRetriedErrors(t *testing.T) {
+	data := `<testsuite name="Tests Suite" tests="2" failures="1" errors="1">
+  <testcase name="flaky" classname="Tests Suite"><failure message="attempt 1"/><error message="attempt 1"/></testcase>
+  <testcase name="flaky" classname="Tests Suite"></testcase>
+</testsuite>`
+
+	testSuite, err := readTestSuite(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Tests != 1 || testSuite.Failures != 0 || testSuite.Errors != 0 {
+		t.Errorf("expected 1 passing test after collapsing retries, got tests=%d failures=%d errors=%d",
+			testSuite.Tests, testSuite.Failures, testSuite.Errors)
 	}
 }
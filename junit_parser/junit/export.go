@@ -0,0 +1,240 @@
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// ExportOptions configure the merged JUnit XML written by Export.
+type ExportOptions struct {
+	// Polarion writes the variant accepted by the Polarion XUnit importer:
+	// every test case is identified by the test-case ID derived from its
+	// test_id tags, and the suite and test case properties are replaced by
+	// the Polarion ones.
+	Polarion bool
+	// TestCaseIDPrefix is prepended to the test_id tags to form the Polarion
+	// test-case IDs, e.g. "CNV-" for "[test_id:1783]" to become "CNV-1783".
+	TestCaseIDPrefix string
+	// Properties are the run properties written at the top of the file, e.g.
+	// the CNV version and the timestamp of the run.
+	Properties []Property
+}
+
+// The export types below define the normalized JUnit XML layout, which is
+// the same whatever the suites' own reporters wrote.
+type exportTestSuites struct {
+	XMLName    xml.Name          `xml:"testsuites"`
+	Name       string            `xml:"name,attr,omitempty"`
+	Tests      int               `xml:"tests,attr"`
+	Failures   int               `xml:"failures,attr"`
+	Errors     int               `xml:"errors,attr"`
+	Skipped    int               `xml:"skipped,attr"`
+	Time       string            `xml:"time,attr"`
+	Properties *exportProperties `xml:"properties,omitempty"`
+	TestSuites []exportTestSuite `xml:"testsuite"`
+}
+
+type exportTestSuite struct {
+	Name       string            `xml:"name,attr"`
+	Tests      int               `xml:"tests,attr"`
+	Failures   int               `xml:"failures,attr"`
+	Errors     int               `xml:"errors,attr"`
+	Skipped    int               `xml:"skipped,attr"`
+	Time       string            `xml:"time,attr"`
+	Properties *exportProperties `xml:"properties,omitempty"`
+	TestCases  []exportTestCase  `xml:"testcase"`
+}
+
+type exportTestCase struct {
+	Name       string            `xml:"name,attr"`
+	Classname  string            `xml:"classname,attr"`
+	Time       string            `xml:"time,attr"`
+	Properties *exportProperties `xml:"properties,omitempty"`
+	Failure    *exportOutcome    `xml:"failure,omitempty"`
+	Error      *exportOutcome    `xml:"error,omitempty"`
+	Skipped    *exportOutcome    `xml:"skipped,omitempty"`
+	SystemOut  string            `xml:"system-out,omitempty"`
+}
+
+type exportOutcome struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+type exportProperties struct {
+	Properties []exportProperty `xml:"property"`
+}
+
+type exportProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// ExportName is the name of the merged <testsuites> element.
+const ExportName = "ocp-virt-validation"
+
+// ExportFile writes the merged JUnit XML of all suites to fileName.
+func ExportFile(fileName string, testSuites map[string]TestSuite, opts ExportOptions) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to create %s; %w", fileName, err)
+	}
+
+	if err := Export(file, testSuites, opts); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s; %w", fileName, err)
+	}
+	return file.Close()
+}
+
+// Export writes a single JUnit XML document holding the test cases of all
+// suites, one <testsuite> per suite in name order. The classnames are
+// prefixed with the suite, and the counts are recomputed from the test cases,
+// so that the Ginkgo, SSP and pytest results share the same shape.
+func Export(w io.Writer, testSuites map[string]TestSuite, opts ExportOptions) error {
+	doc := exportTestSuites{
+		Name:       ExportName,
+		Properties: newExportProperties(opts.Properties),
+	}
+
+	var seconds float64
+	names := make([]string, 0, len(testSuites))
+	for name := range testSuites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		testSuite := testSuites[name]
+		suite, suiteTime := exportSuite(name, testSuite, opts)
+
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Errors += suite.Errors
+		doc.Skipped += suite.Skipped
+		seconds += suiteTime
+		doc.TestSuites = append(doc.TestSuites, suite)
+	}
+	doc.Time = formatSeconds(seconds)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// exportSuite normalizes the suite named name and returns it along with its
+// duration. The suite's own duration is kept when it is known, since the
+// test cases do not account for the setup and teardown of the run.
+func exportSuite(name string, testSuite TestSuite, opts ExportOptions) (exportTestSuite, float64) {
+	suite := exportTestSuite{Name: name}
+	if !opts.Polarion {
+		suite.Properties = newExportProperties(testSuite.Properties)
+	}
+
+	var seconds float64
+	for _, testCase := range testSuite.TestCases {
+		exported := exportTestCase{
+			Name:      testCase.Name,
+			Classname: exportClassname(name, testSuite, testCase),
+			Time:      formatSeconds(testCase.Time),
+			Failure:   newExportOutcome(testCase.Failure),
+			Error:     newExportOutcome(testCase.Error),
+			Skipped:   newExportOutcome(testCase.Skipped),
+			SystemOut: testCase.SystemOut,
+		}
+		seconds += testCase.Time
+
+		var testCases []exportTestCase
+		if opts.Polarion {
+			// The importer identifies every test case by a single ID, so a
+			// test that covers several of them is reported once per ID, and
+			// one without any cannot be reported at all.
+			for _, id := range testCase.Metadata.TestIDs {
+				exported.Properties = newExportProperties([]Property{
+					{Name: "polarion-testcase-id", Value: opts.TestCaseIDPrefix + id},
+				})
+				testCases = append(testCases, exported)
+			}
+		} else {
+			exported.Properties = newExportProperties(testCase.Properties)
+			testCases = append(testCases, exported)
+		}
+
+		for _, exported := range testCases {
+			suite.Tests++
+			switch {
+			case exported.Failure != nil:
+				suite.Failures++
+			case exported.Error != nil:
+				suite.Errors++
+			case exported.Skipped != nil:
+				suite.Skipped++
+			}
+		}
+		suite.TestCases = append(suite.TestCases, testCases...)
+	}
+
+	if testSuite.Time > 0 {
+		seconds = testSuite.Time
+	}
+	suite.Time = formatSeconds(seconds)
+	return suite, seconds
+}
+
+// exportClassname returns the classname of a test case prefixed with its
+// suite, e.g. "tier2.tests.storage.test_snapshot". The Ginkgo JUnit reporter
+// uses the suite description as the classname of every spec, so the spec's
+// top-level container is used instead when it is known.
+func exportClassname(suiteName string, testSuite TestSuite, testCase TestCase) string {
+	classname := testCase.Classname
+	if classname == "" || classname == testSuite.Name {
+		classname = testCase.Container
+	}
+	if classname == "" {
+		return suiteName
+	}
+	return suiteName + "." + classname
+}
+
+// newExportOutcome converts a <failure>, <error> or <skipped> element. An
+// outcome read from a Ginkgo JSON report has no body, so the failure
+// location is written as the body instead.
+func newExportOutcome(outcome *Outcome) *exportOutcome {
+	if outcome == nil {
+		return nil
+	}
+
+	text := outcome.Text
+	if text == "" {
+		text = outcome.Location
+	}
+	return &exportOutcome{Message: outcome.Message, Type: outcome.Type, Text: text}
+}
+
+func newExportProperties(properties []Property) *exportProperties {
+	if len(properties) == 0 {
+		return nil
+	}
+
+	exported := &exportProperties{}
+	for _, property := range properties {
+		exported.Properties = append(exported.Properties, exportProperty(property))
+	}
+	return exported
+}
+
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
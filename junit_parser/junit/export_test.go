@@ -0,0 +1,141 @@
+package junit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func exportFixture() map[string]TestSuite {
+	migration := TestCase{
+		Name:      "[sig-compute]VM Live Migration should migrate [test_id:1783][test_id:1784]",
+		Classname: "Tests Suite",
+		Time:      12.5,
+		Container: "[sig-compute]VM Live Migration",
+		Failure:   &Outcome{Message: "timed out", Type: "failed", Location: "tests/migration_test.go:42"},
+	}
+	migration.Metadata = ParseMetadata(migration.Name, nil)
+
+	return map[string]TestSuite{
+		"compute": {
+			Name:       "Tests Suite",
+			Time:       30,
+			Properties: []Property{{Name: "SuiteSucceeded", Value: "false"}},
+			TestCases: []TestCase{
+				migration,
+				{Name: "[sig-compute]VM should start", Classname: "Tests Suite", Time: 2, Skipped: &Outcome{Type: "skipped"}},
+			},
+		},
+		"tier2": {
+			Name: "pytest",
+			TestCases: []TestCase{
+				{Name: "test_snapshot", Classname: "tests.storage.test_snapshot", Time: 1.25},
+				{Name: "test_bridge", Classname: "tests.network.test_bridge", Time: 0.5, Error: &Outcome{Message: "setup failed", Text: "Traceback"}},
+			},
+		},
+	}
+}
+
+func TestExportsMergedJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	opts := ExportOptions{Properties: []Property{{Name: "cnv-version", Value: "4.21.0"}}}
+	if err := Export(&buf, exportFixture(), opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exported := buf.String()
+
+	var testSuites TestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &testSuites); err != nil {
+		t.Fatalf("failed to parse the export: %v\n%s", err, exported)
+	}
+	if len(testSuites.TestSuites) != 2 {
+		t.Fatalf("expected 2 suites, got %d", len(testSuites.TestSuites))
+	}
+
+	compute, tier2 := testSuites.TestSuites[0], testSuites.TestSuites[1]
+	if compute.Name != "compute" || compute.Tests != 2 || compute.Failures != 1 || compute.Skipped != 1 || compute.Time != 30 {
+		t.Errorf("unexpected compute suite: %+v", compute)
+	}
+	if tier2.Name != "tier2" || tier2.Tests != 2 || tier2.Errors != 1 || tier2.Time != 1.75 {
+		t.Errorf("unexpected tier2 suite: %+v", tier2)
+	}
+
+	expectedClassnames := []string{
+		"compute.[sig-compute]VM Live Migration",
+		"compute",
+		"tier2.tests.storage.test_snapshot",
+		"tier2.tests.network.test_bridge",
+	}
+	var classnames []string
+	for _, suite := range testSuites.TestSuites {
+		for _, testCase := range suite.TestCases {
+			classnames = append(classnames, testCase.Classname)
+		}
+	}
+	if strings.Join(classnames, "|") != strings.Join(expectedClassnames, "|") {
+		t.Errorf("expected classnames %q, got %q", expectedClassnames, classnames)
+	}
+
+	if got := compute.TestCases[0].Failure; got == nil || got.Message != "timed out" || got.Text != "tests/migration_test.go:42" {
+		t.Errorf("expected the failure location as the failure body, got %+v", got)
+	}
+
+	for _, expected := range []string{
+		`<testsuites name="ocp-virt-validation" tests="4" failures="1" errors="1" skipped="1" time="31.750">`,
+		`<property name="cnv-version" value="4.21.0"></property>`,
+		`<property name="SuiteSucceeded" value="false"></property>`,
+		`<skipped type="skipped"></skipped>`,
+	} {
+		if !strings.Contains(exported, expected) {
+			t.Errorf("expected the export to contain %s, got\n%s", expected, exported)
+		}
+	}
+}
+
+func TestExportsPolarionVariant(t *testing.T) {
+	var buf bytes.Buffer
+	opts := ExportOptions{
+		Polarion:         true,
+		TestCaseIDPrefix: "CNV-",
+		Properties:       []Property{{Name: "polarion-project-id", Value: "CNV"}},
+	}
+	if err := Export(&buf, exportFixture(), opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exported := buf.String()
+
+	var testSuites TestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &testSuites); err != nil {
+		t.Fatalf("failed to parse the export: %v\n%s", err, exported)
+	}
+
+	compute := testSuites.TestSuites[0]
+	if compute.Tests != 2 || compute.Failures != 2 || len(compute.TestCases) != 2 {
+		t.Errorf("expected the migration test to be reported once per test_id, got %+v", compute)
+	}
+	if tier2 := testSuites.TestSuites[1]; tier2.Tests != 0 || len(tier2.TestCases) != 0 {
+		t.Errorf("expected the tests without a test_id to be left out, got %+v", tier2)
+	}
+
+	for _, expected := range []string{
+		`<property name="polarion-project-id" value="CNV"></property>`,
+		`<property name="polarion-testcase-id" value="CNV-1783"></property>`,
+		`<property name="polarion-testcase-id" value="CNV-1784"></property>`,
+	} {
+		if !strings.Contains(exported, expected) {
+			t.Errorf("expected the export to contain %s, got\n%s", expected, exported)
+		}
+	}
+	if strings.Contains(exported, "SuiteSucceeded") {
+		t.Errorf("expected the suite properties to be left out, got\n%s", exported)
+	}
+}
+
+func TestExportFileErrors(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "missing", "merged-results.xml")
+	if err := ExportFile(fileName, exportFixture(), ExportOptions{}); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
@@ -0,0 +1,319 @@
+package junit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ginkgoReportFileName = "ginkgo.report.json"
+
+// ginkgoReport mirrors the subset of Ginkgo's types.Report that is written by
+// --ginkgo.json-report and used to build a TestSuite. Its SpecReports are
+// decoded one by one into ginkgoSpecReport.
+type ginkgoReport struct {
+	SuiteDescription string            `json:"SuiteDescription"`
+	SuiteSucceeded   bool              `json:"SuiteSucceeded"`
+	SuiteLabels      []string          `json:"SuiteLabels"`
+	SuiteConfig      ginkgoSuiteConfig `json:"SuiteConfig"`
+	RunTime          time.Duration     `json:"RunTime"`
+}
+
+type ginkgoSuiteConfig struct {
+	RandomSeed    int64    `json:"RandomSeed"`
+	LabelFilter   string   `json:"LabelFilter"`
+	FocusStrings  []string `json:"FocusStrings"`
+	SkipStrings   []string `json:"SkipStrings"`
+	FocusFiles    []string `json:"FocusFiles"`
+	SkipFiles     []string `json:"SkipFiles"`
+	FlakeAttempts int      `json:"FlakeAttempts"`
+	DryRun        bool     `json:"DryRun"`
+}
+
+// properties returns the suite config as the properties written by Ginkgo's
+// JUnit reporter, so that both report formats expose the same metadata.
+func (r ginkgoReport) properties() []Property {
+	c := r.SuiteConfig
+	return []Property{
+		{Name: "SuiteSucceeded", Value: strconv.FormatBool(r.SuiteSucceeded)},
+		{Name: "SuiteLabels", Value: "[" + strings.Join(r.SuiteLabels, ",") + "]"},
+		{Name: "RandomSeed", Value: strconv.FormatInt(c.RandomSeed, 10)},
+		{Name: "LabelFilter", Value: c.LabelFilter},
+		{Name: "FocusStrings", Value: strings.Join(c.FocusStrings, ",")},
+		{Name: "SkipStrings", Value: strings.Join(c.SkipStrings, ",")},
+		{Name: "FocusFiles", Value: strings.Join(c.FocusFiles, ",")},
+		{Name: "SkipFiles", Value: strings.Join(c.SkipFiles, ",")},
+		{Name: "FlakeAttempts", Value: strconv.Itoa(c.FlakeAttempts)},
+		{Name: "DryRun", Value: strconv.FormatBool(c.DryRun)},
+	}
+}
+
+type ginkgoSpecReport struct {
+	ContainerHierarchyTexts    []string       `json:"ContainerHierarchyTexts"`
+	ContainerHierarchyLabels   [][]string     `json:"ContainerHierarchyLabels"`
+	LeafNodeType               string         `json:"LeafNodeType"`
+	LeafNodeLocation           ginkgoLocation `json:"LeafNodeLocation"`
+	LeafNodeLabels             []string       `json:"LeafNodeLabels"`
+	LeafNodeText               string         `json:"LeafNodeText"`
+	State                      string         `json:"State"`
+	RunTime                    time.Duration  `json:"RunTime"`
+	NumAttempts                int            `json:"NumAttempts"`
+	MaxFlakeAttempts           int            `json:"MaxFlakeAttempts"`
+	CapturedGinkgoWriterOutput string         `json:"CapturedGinkgoWriterOutput"`
+	CapturedStdOutErr          string         `json:"CapturedStdOutErr"`
+	Failure                    *ginkgoFailure `json:"Failure"`
+}
+
+type ginkgoLocation struct {
+	FileName   string `json:"FileName"`
+	LineNumber int    `json:"LineNumber"`
+}
+
+func (l ginkgoLocation) String() string {
+	if l.FileName == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", l.FileName, l.LineNumber)
+}
+
+type ginkgoFailure struct {
+	Message         string         `json:"Message"`
+	Location        ginkgoLocation `json:"Location"`
+	FailureNodeType string         `json:"FailureNodeType"`
+}
+
+// readGinkgoReportFile reads a Ginkgo JSON report from the given file.
+func readGinkgoReportFile(fileName string) (TestSuite, error) {
+	reportFile, err := os.Open(fileName)
+	if err != nil {
+		return TestSuite{}, err
+	}
+
+	defer reportFile.Close()
+
+	testSuite, err := readGinkgoReport(reportFile)
+	if err != nil {
+		return testSuite, fmt.Errorf("failed to parse ginkgo report: %s; %v", fileName, err)
+	}
+
+	return testSuite, nil
+}
+
+// readGinkgoReport decodes a Ginkgo JSON report and maps it into the same
+// TestSuite model that is used for JUnit files. A report file holds a list
+// of suite reports; they are merged like multiple <testsuite> elements.
+// The report is decoded one spec at a time, so that only the test cases,
+// with their captured output capped, are kept in memory.
+func readGinkgoReport(reader io.Reader) (TestSuite, error) {
+	decoder := json.NewDecoder(reader)
+	if err := expectDelim(decoder, '['); err != nil {
+		return TestSuite{}, err
+	}
+
+	var suites []TestSuite
+	for decoder.More() {
+		testSuite, err := decodeGinkgoReport(decoder)
+		if err != nil {
+			return TestSuite{}, err
+		}
+		suites = append(suites, testSuite)
+	}
+
+	if err := expectDelim(decoder, ']'); err != nil {
+		return TestSuite{}, err
+	}
+
+	return mergeTestSuites(suites), nil
+}
+
+// decodeGinkgoReport decodes a single suite report, adding its specs to the
+// suite as they are decoded instead of holding all of them at once.
+func decodeGinkgoReport(decoder *json.Decoder) (TestSuite, error) {
+	if err := expectDelim(decoder, '{'); err != nil {
+		return TestSuite{}, err
+	}
+
+	var (
+		report    ginkgoReport
+		testSuite TestSuite
+	)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return TestSuite{}, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return TestSuite{}, fmt.Errorf("expected an object key, got %v", token)
+		}
+
+		switch key {
+		case "SuiteDescription":
+			err = decoder.Decode(&report.SuiteDescription)
+		case "SuiteSucceeded":
+			err = decoder.Decode(&report.SuiteSucceeded)
+		case "SuiteLabels":
+			err = decoder.Decode(&report.SuiteLabels)
+		case "SuiteConfig":
+			err = decoder.Decode(&report.SuiteConfig)
+		case "RunTime":
+			err = decoder.Decode(&report.RunTime)
+		case "SpecReports":
+			err = decodeSpecReports(decoder, &testSuite)
+		default:
+			var skipped json.RawMessage
+			err = decoder.Decode(&skipped)
+		}
+		if err != nil {
+			return TestSuite{}, err
+		}
+	}
+
+	if err := expectDelim(decoder, '}'); err != nil {
+		return TestSuite{}, err
+	}
+
+	testSuite.Name = report.SuiteDescription
+	testSuite.Time = report.RunTime.Seconds()
+	testSuite.Properties = report.properties()
+	return testSuite, nil
+}
+
+// decodeSpecReports decodes the SpecReports list of a suite report, adding
+// each spec to the suite as soon as it is decoded. A null list is accepted.
+func decodeSpecReports(decoder *json.Decoder, testSuite *TestSuite) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected SpecReports to be a list, got %v", token)
+	}
+
+	for decoder.More() {
+		var spec ginkgoSpecReport
+		if err := decoder.Decode(&spec); err != nil {
+			return err
+		}
+		testSuite.addSpec(spec)
+	}
+
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim consumes the next token, which has to be the given delimiter.
+func expectDelim(decoder *json.Decoder, expected json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("expected %q, got %v", expected, token)
+	}
+	return nil
+}
+
+// addSpec adds a spec to the suite and its header counts.
+func (ts *TestSuite) addSpec(spec ginkgoSpecReport) {
+	// Setup and teardown nodes (BeforeSuite, AfterSuite, ReportAfterSuite...)
+	// only matter when they fail, otherwise they would inflate the counts.
+	if spec.LeafNodeType != "It" && !spec.failed() {
+		return
+	}
+
+	testCase := spec.toTestCase()
+	ts.Tests++
+	switch {
+	case testCase.Failure != nil:
+		ts.Failures++
+	case testCase.Error != nil:
+		ts.Errors++
+	case testCase.Skipped != nil:
+		ts.Skipped++
+	}
+	ts.TestCases = append(ts.TestCases, testCase)
+}
+
+func (s ginkgoSpecReport) failed() bool {
+	switch s.State {
+	case "failed", "timedout", "panicked", "interrupted", "aborted":
+		return true
+	}
+	return false
+}
+
+func (s ginkgoSpecReport) toTestCase() TestCase {
+	testCase := TestCase{
+		Name:      s.fullText(),
+		Classname: strings.Join(s.ContainerHierarchyTexts, " "),
+		Time:      s.RunTime.Seconds(),
+		SystemOut: capText(s.CapturedGinkgoWriterOutput + s.CapturedStdOutErr),
+		Labels:    s.labels(),
+		Location:  s.LeafNodeLocation.String(),
+		Attempts:  s.NumAttempts,
+	}
+	if len(s.ContainerHierarchyTexts) > 0 {
+		testCase.Container = s.ContainerHierarchyTexts[0]
+	}
+	testCase.Metadata = ParseMetadata(testCase.Name, testCase.Labels)
+
+	var outcome *Outcome
+	if s.Failure != nil {
+		outcome = &Outcome{
+			Message:  capText(s.Failure.Message),
+			Type:     s.State,
+			Location: s.Failure.Location.String(),
+		}
+	}
+
+	switch s.State {
+	case "failed", "timedout":
+		testCase.Failure = outcome
+	case "panicked", "interrupted", "aborted":
+		testCase.Error = outcome
+	case "skipped", "pending":
+		testCase.Skipped = &Outcome{Type: s.State}
+		if outcome != nil {
+			testCase.Skipped.Message = outcome.Message
+		}
+	}
+
+	// A failed spec without a failure record still has to count as failed.
+	if s.failed() && testCase.Failure == nil && testCase.Error == nil {
+		testCase.Failure = &Outcome{Type: s.State}
+	}
+
+	return testCase
+}
+
+// fullText joins the container texts and the leaf node text the same way
+// the KubeVirt JUnit reporter names its test cases. Suite-level nodes that
+// have no text are named after their node type, e.g. "[BeforeSuite]".
+func (s ginkgoSpecReport) fullText() string {
+	if s.LeafNodeType != "It" && len(s.ContainerHierarchyTexts) == 0 && s.LeafNodeText == "" {
+		return "[" + s.LeafNodeType + "]"
+	}
+	return strings.Join(append(append([]string{}, s.ContainerHierarchyTexts...), s.LeafNodeText), " ")
+}
+
+// labels returns the unique container and leaf labels of the spec.
+func (s ginkgoSpecReport) labels() []string {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, containerLabels := range append(append([][]string{}, s.ContainerHierarchyLabels...), s.LeafNodeLabels) {
+		for _, label := range containerLabels {
+			if !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	return labels
+}
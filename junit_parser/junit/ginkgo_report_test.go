@@ -0,0 +1,172 @@
+package junit
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestReadGinkgoReportFile(t *testing.T) {
+	testSuite, err := readGinkgoReportFile(path.Join("testdata", "ginkgo", ginkgoReportFileName))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Name != "Tests Suite" {
+		t.Errorf("expected suite name 'Tests Suite', got '%s'", testSuite.Name)
+	}
+	if testSuite.Tests != 4 {
+		t.Errorf("expected 4 tests (passed BeforeSuite excluded), got %d", testSuite.Tests)
+	}
+	if testSuite.Failures != 1 {
+		t.Errorf("expected 1 failure, got %d", testSuite.Failures)
+	}
+	if testSuite.Skipped != 1 {
+		t.Errorf("expected 1 skipped, got %d", testSuite.Skipped)
+	}
+	if testSuite.Time != 370.5 {
+		t.Errorf("expected time 370.5, got %v", testSuite.Time)
+	}
+	if len(testSuite.TestCases) != 4 {
+		t.Fatalf("expected 4 test cases, got %d", len(testSuite.TestCases))
+	}
+	for name, expected := range map[string]string{
+		"RandomSeed":    "1747735200",
+		"LabelFilter":   "(sig-compute&&conformance)",
+		"SkipStrings":   `(\[QUARANTINE\])`,
+		"FlakeAttempts": "3",
+	} {
+		if value, ok := testSuite.Property(name); !ok || value != expected {
+			t.Errorf("expected %s to be %q, got %q (found: %v)", name, expected, value, ok)
+		}
+	}
+
+	failed := testSuite.TestCases[0]
+	expectedName := "[rfe_id:393][crit:high][vendor:cnv-qe@redhat.com][level:system][sig-compute] VM Live Migration Starting a VirtualMachineInstance  [test_id:1783]should be successfully migrated multiple times with cloud-init disk"
+	if failed.Name != expectedName {
+		t.Errorf("expected name\n%s\ngot\n%s", expectedName, failed.Name)
+	}
+	if failed.Failure == nil {
+		t.Fatalf("expected the first spec to have failed")
+	}
+	if !strings.HasPrefix(failed.FailureReason(), "Timed out after 180.001s.") {
+		t.Errorf("unexpected failure reason: '%s'", failed.FailureReason())
+	}
+	if failed.Failure.Location != "/go/src/kubevirt.io/kubevirt/tests/libwait/wait.go:76" {
+		t.Errorf("unexpected failure location: '%s'", failed.Failure.Location)
+	}
+	if failed.Location != "/go/src/kubevirt.io/kubevirt/tests/migration/migration.go:1168" {
+		t.Errorf("unexpected spec location: '%s'", failed.Location)
+	}
+	if failed.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", failed.Attempts)
+	}
+	if len(failed.Labels) != 2 || failed.Labels[0] != "sig-compute" || failed.Labels[1] != "conformance" {
+		t.Errorf("unexpected labels: %v", failed.Labels)
+	}
+	if failed.Container != "[rfe_id:393][crit:high][vendor:cnv-qe@redhat.com][level:system][sig-compute] VM Live Migration" {
+		t.Errorf("unexpected top-level container: '%s'", failed.Container)
+	}
+	if failed.Time != 180.1 {
+		t.Errorf("expected time 180.1, got %v", failed.Time)
+	}
+	if failed.SystemOut != "STEP: Starting the VirtualMachineInstance\n" {
+		t.Errorf("unexpected captured output: '%s'", failed.SystemOut)
+	}
+
+	if !testSuite.TestCases[1].IsFlaky() {
+		t.Errorf("expected the spec that passed on its 2nd attempt to be flaky")
+	}
+	if failed.IsFlaky() || testSuite.TestCases[3].IsFlaky() {
+		t.Errorf("expected failed and first-attempt specs not to be flaky")
+	}
+
+	skipped := testSuite.TestCases[2]
+	if !skipped.IsSkipped() {
+		t.Fatalf("expected the third spec to be skipped")
+	}
+	if skipped.Skipped.Message != "Skip: cluster does not have RWX block storage" {
+		t.Errorf("unexpected skip message: '%s'", skipped.Skipped.Message)
+	}
+	if skipped.IsFailed() {
+		t.Errorf("expected skipped spec not to be failed")
+	}
+}
+
+func TestReadGinkgoReportIncludesFailedSetupNodes(t *testing.T) {
+	data := `[{"SuiteDescription": "SSP Suite", "RunTime": 1000000000, "SpecReports": [
+		{"LeafNodeType": "BeforeSuite", "State": "failed", "Failure": {"Message": "SSP CR not found"}},
+		{"ContainerHierarchyTexts": ["DataSources"], "LeafNodeType": "It", "LeafNodeText": "should exist", "State": "skipped"}
+	]}]`
+
+	testSuite, err := readGinkgoReport(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Tests != 2 || testSuite.Failures != 1 || testSuite.Skipped != 1 {
+		t.Errorf("unexpected counts: tests=%d failures=%d skipped=%d", testSuite.Tests, testSuite.Failures, testSuite.Skipped)
+	}
+	if testSuite.TestCases[0].Name != "[BeforeSuite]" {
+		t.Errorf("expected setup node to be named '[BeforeSuite]', got '%s'", testSuite.TestCases[0].Name)
+	}
+	if testSuite.TestCases[0].FailureReason() != "SSP CR not found" {
+		t.Errorf("unexpected failure reason: '%s'", testSuite.TestCases[0].FailureReason())
+	}
+}
+
+func TestNewResultMapPrefersGinkgoReport(t *testing.T) {
+	dir := generateResDir(t, map[string]string{
+		"compute": `<testsuite name="junit" tests="1"><testcase name="from junit"/></testsuite>`,
+		"network": `<testsuite name="junit" tests="1"><testcase name="from junit"/></testsuite>`,
+	})
+
+	report, err := os.ReadFile(path.Join("testdata", "ginkgo", ginkgoReportFileName))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	if err := os.WriteFile(path.Join(dir, "compute", ginkgoReportFileName), report, 0644); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+	if err := os.WriteFile(path.Join(dir, "network", ginkgoReportFileName), []byte("{not json"), 0644); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+
+	result, err := NewResultMap(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result["compute"].Name != "Tests Suite" || result["compute"].Tests != 4 {
+		t.Errorf("expected compute to be read from the ginkgo report, got %+v", result["compute"])
+	}
+	if result["network"].Name != "junit" || result["network"].Tests != 1 {
+		t.Errorf("expected network to fall back to the junit file, got %+v", result["network"])
+	}
+}
+
+func TestReadGinkgoReportCapsCapturedOutput(t *testing.T) {
+	output := strings.Repeat("x", 2*maxTextLength)
+	data := `[{"SuiteDescription": "Tests Suite", "Unknown": {"nested": [1, 2]}, "SpecReports": [
+		{"LeafNodeType": "It", "LeafNodeText": "logs a lot", "State": "failed",
+		 "CapturedGinkgoWriterOutput": "` + output + `", "CapturedStdOutErr": "` + output + `",
+		 "Failure": {"Message": "` + output + `"}}
+	], "SuiteSucceeded": false}]`
+
+	testSuite, err := readGinkgoReport(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Name != "Tests Suite" || len(testSuite.TestCases) != 1 {
+		t.Fatalf("unexpected suite: %+v", testSuite)
+	}
+	testCase := testSuite.TestCases[0]
+	if len(testCase.SystemOut) != maxTextLength {
+		t.Errorf("expected the captured output to be capped at %d bytes, got %d", maxTextLength, len(testCase.SystemOut))
+	}
+	if len(testCase.Failure.Message) != maxTextLength {
+		t.Errorf("expected the failure message to be capped at %d bytes, got %d", maxTextLength, len(testCase.Failure.Message))
+	}
+}
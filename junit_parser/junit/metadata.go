@@ -0,0 +1,185 @@
+package junit
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Metadata holds the tags that KubeVirt embeds in its spec names, e.g.
+// "[rfe_id:393][crit:high][level:system][sig-compute] ... [test_id:1783]".
+type Metadata struct {
+	TestIDs []string
+	RFEIDs  []string
+	Crit    string
+	Level   string
+	Sigs    []string
+}
+
+var (
+	keyValueTagPattern = regexp.MustCompile(`\[(test_id|rfe_id|crit|level):\s*([^\]]+?)\s*\]`)
+	sigTagPattern      = regexp.MustCompile(`\[(sig-[A-Za-z0-9-]+)\]`)
+)
+
+// ParseMetadata extracts the metadata tags from a test name. Ginkgo labels
+// are parsed as well, so that a "sig-compute" label counts like a
+// "[sig-compute]" tag. When a tag that holds a single value appears more
+// than once, the first occurrence wins.
+func ParseMetadata(name string, labels []string) Metadata {
+	var md Metadata
+
+	texts := []string{name}
+	for _, label := range labels {
+		texts = append(texts, "["+label+"]")
+	}
+
+	for _, text := range texts {
+		for _, match := range keyValueTagPattern.FindAllStringSubmatch(text, -1) {
+			value := match[2]
+			switch match[1] {
+			case "test_id":
+				md.TestIDs = appendUnique(md.TestIDs, value)
+			case "rfe_id":
+				md.RFEIDs = appendUnique(md.RFEIDs, value)
+			case "crit":
+				if md.Crit == "" {
+					md.Crit = strings.ToLower(value)
+				}
+			case "level":
+				if md.Level == "" {
+					md.Level = strings.ToLower(value)
+				}
+			}
+		}
+
+		for _, match := range sigTagPattern.FindAllStringSubmatch(text, -1) {
+			md.Sigs = appendUnique(md.Sigs, match[1])
+		}
+	}
+
+	return md
+}
+
+// PropertyLabels turns the test case properties named like a metadata tag,
+// e.g. the crit and level pytest markers recorded as properties, into labels
+// for ParseMetadata, e.g. "crit:high".
+func PropertyLabels(properties []Property) []string {
+	var labels []string
+	for _, property := range properties {
+		value := strings.TrimSpace(property.Value)
+		switch property.Name {
+		case "test_id", "rfe_id", "crit", "level":
+			if value != "" {
+				labels = append(labels, property.Name+":"+value)
+			}
+		}
+	}
+	return labels
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// Filter selects test cases by criticality and level. An empty list matches
+// every value; otherwise a test case matches when its value is in the list.
+type Filter struct {
+	Crit  []string
+	Level []string
+}
+
+// IsEmpty reports whether the filter matches every test case.
+func (f Filter) IsEmpty() bool {
+	return len(f.Crit) == 0 && len(f.Level) == 0
+}
+
+// Matches reports whether the test case is selected by the filter.
+func (f Filter) Matches(testCase TestCase) bool {
+	return matchesAny(f.Crit, testCase.Metadata.Crit) && matchesAny(f.Level, testCase.Metadata.Level)
+}
+
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns the suites restricted to the test cases that match the
+// filter, with their counts recomputed from those test cases. Suites left
+// without any test case are dropped, unless they failed during setup or
+// their results are incomplete.
+func (f Filter) Apply(testSuites map[string]TestSuite) map[string]TestSuite {
+	if f.IsEmpty() {
+		return testSuites
+	}
+
+	filtered := make(map[string]TestSuite, len(testSuites))
+	for sig, testSuite := range testSuites {
+		if testSuite.SetupFailure {
+			filtered[sig] = testSuite
+			continue
+		}
+		if suite := f.applyToSuite(testSuite); len(suite.TestCases) > 0 || suite.Incomplete {
+			filtered[sig] = suite
+		}
+	}
+	return filtered
+}
+
+// applyToSuite returns a copy of the suite with only the matching test cases.
+// What describes the run rather than its tests, e.g. its status, properties
+// and phases, is kept whatever the filter.
+func (f Filter) applyToSuite(testSuite TestSuite) TestSuite {
+	filtered := testSuite
+	filtered.Tests, filtered.Failures, filtered.Errors, filtered.Skipped, filtered.Disabled = 0, 0, 0, 0, 0
+	filtered.Time = 0
+	filtered.TestCases = nil
+	filtered.SubSuites = nil
+
+	for _, testCase := range testSuite.TestCases {
+		if !f.Matches(testCase) {
+			continue
+		}
+		filtered.Tests++
+		switch {
+		case testCase.Failure != nil:
+			filtered.Failures++
+		case testCase.Error != nil:
+			filtered.Errors++
+		case testCase.Skipped != nil:
+			filtered.Skipped++
+		}
+		filtered.Time += testCase.Time
+		filtered.TestCases = append(filtered.TestCases, testCase)
+	}
+
+	for _, subSuite := range testSuite.SubSuites {
+		if sub := f.applyToSuite(subSuite); len(sub.TestCases) > 0 {
+			filtered.SubSuites = append(filtered.SubSuites, sub)
+		}
+	}
+
+	return filtered
+}
+
+// String describes the filter, e.g. "crit:high,critical level:system".
+func (f Filter) String() string {
+	var parts []string
+	if len(f.Crit) > 0 {
+		parts = append(parts, "crit:"+strings.Join(f.Crit, ","))
+	}
+	if len(f.Level) > 0 {
+		parts = append(parts, "level:"+strings.Join(f.Level, ","))
+	}
+	return strings.Join(parts, " ")
+}
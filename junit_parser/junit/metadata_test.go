@@ -0,0 +1,174 @@
+package junit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMetadata(t *testing.T) {
+	name := "[rfe_id:393][crit:High][vendor:cnv-qe@redhat.com][level:system][sig-compute] Live Migration " +
+		"[test_id:1783][test_id:1784]should be successfully migrated [sig-compute]"
+
+	md := ParseMetadata(name, []string{"sig-storage", "conformance"})
+
+	expected := Metadata{
+		TestIDs: []string{"1783", "1784"},
+		RFEIDs:  []string{"393"},
+		Crit:    "high",
+		Level:   "system",
+		Sigs:    []string{"sig-compute", "sig-storage"},
+	}
+	if !reflect.DeepEqual(md, expected) {
+		t.Errorf("expected %+v, got %+v", expected, md)
+	}
+}
+
+func TestParseMetadataWithoutTags(t *testing.T) {
+	md := ParseMetadata("test_hotplug_disk", nil)
+	if !reflect.DeepEqual(md, Metadata{}) {
+		t.Errorf("expected empty metadata, got %+v", md)
+	}
+}
+
+func TestDecodedTestCasesCarryMetadata(t *testing.T) {
+	testSuite, err := readTestSuite(strings.NewReader(`<testsuite tests="1">
+  <testcase name="[crit:medium][level:component][sig-network] SR-IOV [test_id:3957]should connect" classname="Tests Suite"/>
+</testsuite>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	md := testSuite.TestCases[0].Metadata
+	if md.Crit != "medium" || md.Level != "component" || !reflect.DeepEqual(md.TestIDs, []string{"3957"}) {
+		t.Errorf("unexpected metadata: %+v", md)
+	}
+}
+
+func TestDecodedPytestCasesCarryMarkerProperties(t *testing.T) {
+	testSuite, err := readTestSuite(strings.NewReader(`<testsuite tests="1">
+  <testcase name="test_migrate_vm" classname="tests.virt.test_migration">
+    <properties>
+      <property name="crit" value="High"/>
+      <property name="level" value="system"/>
+      <property name="test_id" value="5678"/>
+      <property name="owner" value="virt"/>
+    </properties>
+  </testcase>
+</testsuite>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := Metadata{TestIDs: []string{"5678"}, Crit: "high", Level: "system"}
+	if md := testSuite.TestCases[0].Metadata; !reflect.DeepEqual(md, expected) {
+		t.Errorf("expected %+v, got %+v", expected, md)
+	}
+}
+
+func TestFilterApply(t *testing.T) {
+	testCase := func(name string, outcome string) TestCase {
+		tc := TestCase{Name: name, Time: 1, Metadata: ParseMetadata(name, nil)}
+		switch outcome {
+		case "failure":
+			tc.Failure = &Outcome{}
+		case "error":
+			tc.Error = &Outcome{}
+		case "skipped":
+			tc.Skipped = &Outcome{}
+		}
+		return tc
+	}
+
+	testSuites := map[string]TestSuite{
+		"compute": {
+			Name:     "Tests Suite",
+			Tests:    4,
+			Failures: 1,
+			TestCases: []TestCase{
+				testCase("[crit:high][level:system] a", "failure"),
+				testCase("[crit:high][level:component] b", ""),
+				testCase("[crit:high][level:system] c", "skipped"),
+				testCase("[crit:low][level:system] d", "error"),
+			},
+		},
+		"tier2": {
+			Tests:     1,
+			TestCases: []TestCase{testCase("test_untagged", "")},
+		},
+		"ssp": {SetupFailure: true},
+	}
+
+	filtered := Filter{Crit: []string{"HIGH"}, Level: []string{"system"}}.Apply(testSuites)
+
+	if _, ok := filtered["tier2"]; ok {
+		t.Error("expected tier2 to be dropped, it has no matching test")
+	}
+	if _, ok := filtered["ssp"]; !ok {
+		t.Error("expected the setup failure of ssp to be kept")
+	}
+
+	compute := filtered["compute"]
+	if compute.Tests != 2 || compute.Failures != 1 || compute.Errors != 0 || compute.Skipped != 1 || compute.Time != 2 {
+		t.Errorf("unexpected counts: %+v", compute)
+	}
+	if len(compute.TestCases) != 2 || compute.TestCases[0].Name != "[crit:high][level:system] a" {
+		t.Errorf("unexpected test cases: %+v", compute.TestCases)
+	}
+}
+
+func TestFilterApplyKeepsSuiteRunData(t *testing.T) {
+	testSuites := map[string]TestSuite{
+		"compute": {
+			Name:       "Tests Suite",
+			Tests:      2,
+			Failures:   1,
+			Disabled:   3,
+			Status:     StatusTimedOut,
+			Incomplete: true,
+			Expected:   10,
+			Properties: []Property{{Name: "RandomSeed", Value: "42"}},
+			Phases:     []Phase{{Name: "tests"}},
+			TestCases: []TestCase{
+				{Name: "[crit:high] a", Failure: &Outcome{}, Metadata: ParseMetadata("[crit:high] a", nil)},
+				{Name: "[crit:low] b", Metadata: ParseMetadata("[crit:low] b", nil)},
+			},
+		},
+		"network": {
+			Status:     StatusInterrupted,
+			Incomplete: true,
+			TestCases:  []TestCase{{Name: "[crit:low] c", Metadata: ParseMetadata("[crit:low] c", nil)}},
+		},
+	}
+
+	filtered := Filter{Crit: []string{"high"}}.Apply(testSuites)
+
+	compute := filtered["compute"]
+	if compute.Tests != 1 || compute.Failures != 1 || compute.Disabled != 0 {
+		t.Errorf("unexpected counts: %+v", compute)
+	}
+	if compute.Status != StatusTimedOut || !compute.Incomplete || compute.Expected != 10 {
+		t.Errorf("expected the status and the incomplete run to be kept, got %+v", compute)
+	}
+	if value, ok := compute.Property("RandomSeed"); !ok || value != "42" || len(compute.Phases) != 1 {
+		t.Errorf("expected the properties and phases to be kept, got %+v", compute)
+	}
+	if network, ok := filtered["network"]; !ok || network.Status != StatusInterrupted || len(network.TestCases) != 0 {
+		t.Errorf("expected the incomplete network suite to be kept without tests, got %+v (found: %v)", network, ok)
+	}
+}
+
+func TestEmptyFilterKeepsAllSuites(t *testing.T) {
+	testSuites := map[string]TestSuite{"tier2": {Tests: 1, TestCases: []TestCase{{Name: "test_a"}}}}
+
+	filtered := Filter{}.Apply(testSuites)
+	if !reflect.DeepEqual(filtered, testSuites) {
+		t.Errorf("expected suites to be unchanged, got %+v", filtered)
+	}
+	if s := (Filter{}).String(); s != "" {
+		t.Errorf("expected an empty description, got %q", s)
+	}
+	if s := (Filter{Crit: []string{"high", "critical"}, Level: []string{"system"}}).String(); s != "crit:high,critical level:system" {
+		t.Errorf("unexpected description: %q", s)
+	}
+}
@@ -0,0 +1,118 @@
+package junit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PhasesFileName is the file the runner scripts record the phases of a run
+// in: in the suite directory for the phases of a suite, and in the results
+// directory for the ones of the whole run, e.g. the Windows image setup.
+const PhasesFileName = ".phases"
+
+// Markers of the lines of a phases file.
+const (
+	PhaseStart = "start"
+	PhaseEnd   = "end"
+)
+
+// Phase is a step of a run as recorded by the runner scripts, e.g. the
+// rollout of the disks-images-provider, the tests themselves or the cleanup
+// of the test namespaces.
+type Phase struct {
+	Name  string
+	Start time.Time
+	// End is zero when the phase never ended, e.g. when the run was killed
+	// during it.
+	End time.Time
+}
+
+// Duration returns how long the phase took, or zero when it never ended.
+func (p Phase) Duration() time.Duration {
+	if p.End.IsZero() {
+		return 0
+	}
+	return p.End.Sub(p.Start)
+}
+
+// ReadPhases reads the phases recorded in fileName, in the order they
+// started. A missing file yields no phases. Every line of the file is a
+// "<RFC 3339 time> start|end <phase>" marker, and an end marker closes the
+// last open phase of the same name. Malformed lines are skipped with a
+// warning, since a run that was killed may leave a partial line behind.
+func ReadPhases(fileName string) ([]Phase, error) {
+	phasesFile, err := os.Open(fileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open phases file %s; %w", fileName, err)
+	}
+	defer phasesFile.Close()
+
+	var phases []Phase
+	open := make(map[string]int)
+	scanner := bufio.NewScanner(phasesFile)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 || strings.TrimSpace(fields[2]) == "" {
+			fmt.Fprintf(os.Stderr, "WARNING: skipping malformed line %d of %s: %q\n", lineNumber, fileName, line)
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, fields[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: skipping malformed line %d of %s; %v\n", lineNumber, fileName, err)
+			continue
+		}
+		name := strings.TrimSpace(fields[2])
+
+		switch fields[1] {
+		case PhaseStart:
+			open[name] = len(phases)
+			phases = append(phases, Phase{Name: name, Start: at})
+		case PhaseEnd:
+			i, ok := open[name]
+			if !ok {
+				fmt.Fprintf(os.Stderr, "WARNING: skipping line %d of %s: phase %q was not started\n", lineNumber, fileName, name)
+				continue
+			}
+			phases[i].End = at
+			delete(open, name)
+		default:
+			fmt.Fprintf(os.Stderr, "WARNING: skipping malformed line %d of %s: unknown marker %q\n", lineNumber, fileName, fields[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read phases file %s; %w", fileName, err)
+	}
+
+	sort.SliceStable(phases, func(i, j int) bool {
+		return phases[i].Start.Before(phases[j].Start)
+	})
+	return phases, nil
+}
+
+// AppendPhaseMarker records the start or the end of a phase in fileName, the
+// same way the runner scripts do.
+func AppendPhaseMarker(fileName, marker, phase string, at time.Time) error {
+	phasesFile, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open phases file %s; %w", fileName, err)
+	}
+
+	line := fmt.Sprintf("%s %s %s\n", at.UTC().Format(time.RFC3339Nano), marker, phase)
+	if _, err := phasesFile.WriteString(line); err != nil {
+		phasesFile.Close()
+		return fmt.Errorf("failed to write phases file %s; %w", fileName, err)
+	}
+	return phasesFile.Close()
+}
@@ -0,0 +1,110 @@
+package junit
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestReadPhases(t *testing.T) {
+	fileName := path.Join(t.TempDir(), PhasesFileName)
+	content := `2026-10-16T12:00:00Z start setup
+2026-10-16T12:00:30.5Z end setup
+2026-10-16T12:00:31Z start disk-images-provider
+2026-10-16T12:02:31Z end disk-images-provider
+2026-10-16T12:02:32Z start tests
+2026-10-16T12:00:00Z end namespace-cleanup
+not a marker
+2026-10-16T13:00:00Z pause tests
+2026-10-16T13:00:00Z start
+`
+	if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write phases file: %v", err)
+	}
+
+	phases, err := ReadPhases(fileName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"setup", 30500 * time.Millisecond},
+		{"disk-images-provider", 2 * time.Minute},
+		{"tests", 0},
+	}
+	if len(phases) != len(expected) {
+		t.Fatalf("expected %d phases, got %+v", len(expected), phases)
+	}
+	for i, phase := range phases {
+		if phase.Name != expected[i].name || phase.Duration() != expected[i].duration {
+			t.Errorf("expected phase %d to be %s taking %s, got %s taking %s",
+				i, expected[i].name, expected[i].duration, phase.Name, phase.Duration())
+		}
+	}
+	if !phases[2].End.IsZero() {
+		t.Errorf("expected the tests phase to have no end, got %s", phases[2].End)
+	}
+}
+
+func TestReadPhasesWithoutFile(t *testing.T) {
+	phases, err := ReadPhases(path.Join(t.TempDir(), PhasesFileName))
+	if err != nil || phases != nil {
+		t.Errorf("expected no phases and no error, got %+v and %v", phases, err)
+	}
+}
+
+func TestAppendPhaseMarker(t *testing.T) {
+	fileName := path.Join(t.TempDir(), PhasesFileName)
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	for _, marker := range []struct {
+		kind string
+		at   time.Time
+	}{
+		{PhaseStart, start},
+		{PhaseEnd, start.Add(90 * time.Second)},
+	} {
+		if err := AppendPhaseMarker(fileName, marker.kind, "dry-run-discovery", marker.at); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	phases, err := ReadPhases(fileName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(phases) != 1 || phases[0].Name != "dry-run-discovery" || !phases[0].Start.Equal(start) || phases[0].Duration() != 90*time.Second {
+		t.Errorf("expected the recorded phase to be read back, got %+v", phases)
+	}
+}
+
+func TestReadResultsAttachesPhases(t *testing.T) {
+	dir := generateResDir(t, map[string]string{
+		"compute": `<testsuite name="suite1" tests="1"><testcase name="test"></testcase></testsuite>`,
+		"network": "",
+	})
+	for _, sig := range []string{"compute", "network"} {
+		content := "2026-10-16T12:00:00Z start tests\n2026-10-16T12:10:00Z end tests\n"
+		if err := os.WriteFile(path.Join(dir, sig, PhasesFileName), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write phases file: %v", err)
+		}
+		if err := os.WriteFile(path.Join(dir, sig, ".exit_code"), []byte("1"), 0644); err != nil {
+			t.Fatalf("failed to write exit code: %v", err)
+		}
+	}
+
+	results, _, err := DefaultRegistry().ReadResults(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, sig := range []string{"compute", "network"} {
+		if phases := results[sig].Phases; len(phases) != 1 || phases[0].Duration() != 10*time.Minute {
+			t.Errorf("expected the phases of %s to be read, got %+v", sig, phases)
+		}
+	}
+}
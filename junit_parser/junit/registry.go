@@ -0,0 +1,180 @@
+package junit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Format is the file format of a result source.
+type Format string
+
+const (
+	FormatJUnit      Format = "junit"
+	FormatGinkgoJSON Format = "ginkgo-json"
+	// FormatLog is the console output of a Ginkgo or pytest run. It only
+	// yields the tests that completed, and is meant as a last resort.
+	FormatLog Format = "log"
+)
+
+// readers maps every supported format to the function that reads one file of it.
+var readers = map[Format]func(fileName string) (TestSuite, error){
+	FormatJUnit:      readOneFile,
+	FormatGinkgoJSON: readGinkgoReportFile,
+	FormatLog:        readSuiteLogFile,
+}
+
+// Source describes where the results of a suite are found: a glob relative to
+// the suite directory and the format of the matching files. When the glob
+// matches several files their test suites are merged.
+type Source struct {
+	Glob   string `json:"glob"`
+	Format Format `json:"format"`
+}
+
+// Registry maps a suite directory name to the sources to read its results
+// from, in order of preference. Directories that are neither registered nor
+// ignored are reported as unknown instead of being parsed.
+type Registry struct {
+	Suites map[string][]Source `json:"suites"`
+	Ignore []string            `json:"ignore,omitempty"`
+}
+
+// DefaultRegistry returns the sources of the suites run by the checkup. The
+// Ginkgo suites prefer their JSON report, since it carries labels, code
+// locations and flake attempts that JUnit does not. All suites fall back to
+// their log, so that a run that was killed still reports how far it got.
+func DefaultRegistry() *Registry {
+	ginkgo := func(suite string) []Source {
+		return []Source{
+			{Glob: ginkgoReportFileName, Format: FormatGinkgoJSON},
+			{Glob: junitFileName, Format: FormatJUnit},
+			{Glob: suite + suiteLogSuffix, Format: FormatLog},
+		}
+	}
+
+	return &Registry{
+		Suites: map[string][]Source{
+			"compute": ginkgo("compute"),
+			"network": ginkgo("network"),
+			"storage": ginkgo("storage"),
+			"ssp":     ginkgo("ssp"),
+			"tier2": {
+				{Glob: junitFileName, Format: FormatJUnit},
+				{Glob: "tier2" + suiteLogSuffix, Format: FormatLog},
+			},
+		},
+		Ignore: []string{
+			"lost+found",
+			// used by progress_watcher for test discovery
+			".dry-run",
+		},
+	}
+}
+
+// LoadFile merges the registry stored as JSON in fileName into r. Suites
+// defined in the file replace the sources of suites with the same name.
+func (r *Registry) LoadFile(fileName string) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to read result sources file %s; %w", fileName, err)
+	}
+
+	var loaded Registry
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("failed to parse result sources file %s; %w", fileName, err)
+	}
+
+	if err := loaded.validate(); err != nil {
+		return fmt.Errorf("invalid result sources file %s; %w", fileName, err)
+	}
+
+	if r.Suites == nil {
+		r.Suites = make(map[string][]Source)
+	}
+	for suite, sources := range loaded.Suites {
+		r.Suites[suite] = sources
+	}
+	r.Ignore = append(r.Ignore, loaded.Ignore...)
+
+	return nil
+}
+
+func (r *Registry) validate() error {
+	for suite, sources := range r.Suites {
+		if len(sources) == 0 {
+			return fmt.Errorf("suite %q has no sources", suite)
+		}
+		for _, source := range sources {
+			if source.Glob == "" {
+				return fmt.Errorf("suite %q has a source without a glob", suite)
+			}
+			if _, err := filepath.Match(source.Glob, ""); err != nil {
+				return fmt.Errorf("suite %q has an invalid glob %q; %w", suite, source.Glob, err)
+			}
+			if _, ok := readers[source.Format]; !ok {
+				return fmt.Errorf("suite %q has an unknown format %q", suite, source.Format)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Registry) isIgnored(dirName string) bool {
+	for _, ignored := range r.Ignore {
+		if ignored == dirName {
+			return true
+		}
+	}
+	return false
+}
+
+// readSuiteDir reads the results of a single suite from the first of its
+// sources that matches a file and parses successfully.
+func readSuiteDir(suiteDir string, sources []Source) (TestSuite, error) {
+	for i, source := range sources {
+		fileNames, err := filepath.Glob(filepath.Join(suiteDir, source.Glob))
+		if err != nil {
+			return TestSuite{}, err
+		}
+		if len(fileNames) == 0 {
+			continue
+		}
+		sort.Strings(fileNames)
+
+		testSuite, err := readSourceFiles(fileNames, readers[source.Format])
+		if err == nil {
+			return testSuite, nil
+		}
+
+		if i == len(sources)-1 {
+			return testSuite, err
+		}
+		fmt.Fprintf(os.Stderr, "%v; falling back to %s\n", err, sources[i+1].Glob)
+	}
+
+	return TestSuite{}, fmt.Errorf("no result file found in %s; looked for %s", suiteDir, globs(sources))
+}
+
+func readSourceFiles(fileNames []string, read func(string) (TestSuite, error)) (TestSuite, error) {
+	testSuites := make([]TestSuite, 0, len(fileNames))
+	for _, fileName := range fileNames {
+		testSuite, err := read(fileName)
+		if err != nil {
+			return testSuite, err
+		}
+		testSuites = append(testSuites, testSuite)
+	}
+	return mergeTestSuites(testSuites), nil
+}
+
+func globs(sources []Source) string {
+	patterns := make([]string, 0, len(sources))
+	for _, source := range sources {
+		patterns = append(patterns, source.Glob)
+	}
+	return strings.Join(patterns, ", ")
+}
@@ -0,0 +1,141 @@
+package junit
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func TestReadResultsReportsUnknownDirectories(t *testing.T) {
+	dir := generateResDir(t, map[string]string{
+		"compute":    `<testsuite name="compute" tests="1"><testcase name="a"/></testsuite>`,
+		"my-suite":   `<testsuite name="mine" tests="1"><testcase name="b"/></testsuite>`,
+		"lost+found": "",
+		".dry-run":   "",
+	})
+
+	result, unknown, err := DefaultRegistry().ReadResults(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result) != 1 {
+		t.Errorf("expected 1 result, got %d", len(result))
+	}
+	if _, ok := result["compute"]; !ok {
+		t.Errorf("expected result for compute")
+	}
+	if len(unknown) != 1 || unknown[0] != "my-suite" {
+		t.Errorf("expected my-suite to be reported as unknown, got %v", unknown)
+	}
+}
+
+func TestReadResultsMergesFilesMatchingGlob(t *testing.T) {
+	dir := t.TempDir()
+	suiteDir := path.Join(dir, "perf")
+	if err := os.MkdirAll(path.Join(suiteDir, "reports"), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	files := map[string]string{
+		"junit-1.xml": `<testsuite name="first" tests="2" failures="1"><testcase name="a"><failure/></testcase><testcase name="b"/></testsuite>`,
+		"junit-2.xml": `<testsuite name="second" tests="1"><testcase name="c"/></testsuite>`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(path.Join(suiteDir, "reports", name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	registry := &Registry{Suites: map[string][]Source{
+		"perf": {{Glob: "reports/junit-*.xml", Format: FormatJUnit}},
+	}}
+
+	result, unknown, err := registry.ReadResults(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(unknown) != 0 {
+		t.Errorf("expected no unknown directories, got %v", unknown)
+	}
+
+	perf := result["perf"]
+	if perf.Name != "first" || perf.Tests != 3 || perf.Failures != 1 || len(perf.TestCases) != 3 {
+		t.Errorf("expected merged perf suite, got %+v", perf)
+	}
+	if len(perf.SubSuites) != 2 {
+		t.Errorf("expected 2 sub-suites, got %d", len(perf.SubSuites))
+	}
+}
+
+func TestReadResultsReportsMissingSourceFiles(t *testing.T) {
+	dir := generateResDir(t, map[string]string{"tier2": ""})
+
+	result, unknown, err := DefaultRegistry().ReadResults(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 0 || len(unknown) != 0 {
+		t.Errorf("expected no results and no unknown directories, got %v and %v", result, unknown)
+	}
+
+	_, err = readSuiteDir(path.Join(dir, "tier2"), DefaultRegistry().Suites["tier2"])
+	if err == nil {
+		t.Fatal("expected an error for a suite without result files")
+	}
+}
+
+func TestRegistryLoadFile(t *testing.T) {
+	fileName := path.Join(t.TempDir(), "sources.json")
+	content := `{
+  "suites": {
+    "tier2": [{"glob": "pytest-*.xml", "format": "junit"}],
+    "my-suite": [{"glob": "report.json", "format": "ginkgo-json"}]
+  },
+  "ignore": ["scratch"]
+}`
+	if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	registry := DefaultRegistry()
+	if err := registry.LoadFile(fileName); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sources := registry.Suites["tier2"]; len(sources) != 1 || sources[0].Glob != "pytest-*.xml" {
+		t.Errorf("expected tier2 sources to be replaced, got %v", sources)
+	}
+	if sources := registry.Suites["my-suite"]; len(sources) != 1 || sources[0].Format != FormatGinkgoJSON {
+		t.Errorf("expected my-suite to be registered, got %v", sources)
+	}
+	if len(registry.Suites["compute"]) != 3 {
+		t.Errorf("expected compute sources to be kept, got %v", registry.Suites["compute"])
+	}
+	if !registry.isIgnored("scratch") || !registry.isIgnored("lost+found") {
+		t.Errorf("expected scratch and lost+found to be ignored, got %v", registry.Ignore)
+	}
+}
+
+func TestRegistryLoadFileRejectsInvalidSources(t *testing.T) {
+	tests := map[string]string{
+		"unknown format": `{"suites": {"s": [{"glob": "*.xml", "format": "tap"}]}}`,
+		"empty glob":     `{"suites": {"s": [{"format": "junit"}]}}`,
+		"invalid glob":   `{"suites": {"s": [{"glob": "[", "format": "junit"}]}}`,
+		"no sources":     `{"suites": {"s": []}}`,
+		"invalid json":   `{"suites":`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			fileName := path.Join(t.TempDir(), "sources.json")
+			if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			if err := DefaultRegistry().LoadFile(fileName); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
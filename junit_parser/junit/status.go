@@ -0,0 +1,161 @@
+package junit
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Status is the outcome of a suite run as a whole, as opposed to the
+// outcome of its individual tests.
+type Status string
+
+const (
+	// StatusCompleted means that the suite ran to the end and all of its tests passed.
+	StatusCompleted Status = "completed"
+	// StatusCompletedWithFailures means that the suite ran to the end, but some of its tests failed.
+	StatusCompletedWithFailures Status = "completed-with-failures"
+	// StatusTimedOut means that the suite was stopped by the Ginkgo suite
+	// timeout or the go test timeout.
+	StatusTimedOut Status = "timed-out"
+	// StatusInterrupted means that the suite was stopped by a signal, e.g.
+	// SIGTERM when the Job was deleted, before it could finish.
+	StatusInterrupted Status = "interrupted"
+	// StatusSetupFailed means that the suite crashed before running any test,
+	// e.g. in a BeforeSuite node or during pytest collection.
+	StatusSetupFailed Status = "setup-failed"
+	// StatusNoTestsSelected means that the filters of the run selected zero
+	// tests, e.g. pytest exit code 5.
+	StatusNoTestsSelected Status = "no-tests-selected"
+	// StatusNotStarted means that the suite was supposed to run but left no
+	// trace of having started.
+	StatusNotStarted Status = "not-started"
+)
+
+// Exit codes of the test binaries that identify a status on their own.
+const (
+	exitCodeSIGINT  = 130
+	exitCodeSIGKILL = 137
+	exitCodeSIGTERM = 143
+
+	pytestExitInterrupted   = 2
+	pytestExitInternalError = 3
+	pytestExitUsageError    = 4
+	pytestExitNoTests       = 5
+)
+
+var (
+	timedOutLogPattern    = regexp.MustCompile(`Suite Timeout Elapsed|A suite timeout occurred|panic: test timed out after`)
+	interruptedLogPattern = regexp.MustCompile(`Interrupted by User|Received interrupt|^!+ KeyboardInterrupt !+`)
+	setupFailedLogPattern = regexp.MustCompile(`^\[(?:Synchronized)?BeforeSuite\] \[(?:FAILED|PANICKED)\]|^INTERNALERROR>|^_+ ERROR collecting |^!+ Interrupted: \d+ errors? during collection`)
+	startedLogPattern     = regexp.MustCompile(`Will run \d+ of \d+ specs|^=+ test session starts =+|collected \d+ items`)
+	finishedLogPattern    = regexp.MustCompile(`(?i)^Ran \d+ of \d+ specs? in|^=+ .*\bin [\d.]+s\b.*=+$`)
+	noTestsLogPattern     = regexp.MustCompile(`Will run 0 of \d+ specs|^=+ .*no tests ran.* =+$|^=+ \d+ deselected in `)
+	pytestLogPattern      = regexp.MustCompile(`^=+ test session starts =+`)
+)
+
+// logMarkers holds what the console output of a suite run tells about how
+// the run ended.
+type logMarkers struct {
+	started     bool
+	finished    bool
+	timedOut    bool
+	interrupted bool
+	setupFailed bool
+	noTests     bool
+	pytest      bool
+}
+
+// readLogMarkers scans the log of a suite run for the lines that Ginkgo and
+// pytest print when a run starts, ends, times out or is interrupted.
+func readLogMarkers(fileName string) logMarkers {
+	logFile, err := os.Open(fileName)
+	if err != nil {
+		return logMarkers{}
+	}
+	defer logFile.Close()
+
+	markers := logMarkers{}
+	scanner := bufio.NewScanner(logFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		markers.started = markers.started || startedLogPattern.MatchString(line)
+		markers.finished = markers.finished || finishedLogPattern.MatchString(line)
+		markers.timedOut = markers.timedOut || timedOutLogPattern.MatchString(line)
+		markers.interrupted = markers.interrupted || interruptedLogPattern.MatchString(line)
+		markers.setupFailed = markers.setupFailed || setupFailedLogPattern.MatchString(line)
+		markers.noTests = markers.noTests || noTestsLogPattern.MatchString(line)
+		markers.pytest = markers.pytest || pytestLogPattern.MatchString(line)
+	}
+
+	return markers
+}
+
+// deriveStatus determines the status of a suite run from its exit code, if
+// it was recorded, the markers of its log and its results. testSuite is nil
+// when no result could be read for the suite.
+func deriveStatus(testSuite *TestSuite, exitCode int, hasExitCode bool, markers logMarkers) Status {
+	pytestExit := func(code int) bool {
+		return hasExitCode && markers.pytest && exitCode == code
+	}
+
+	// Log markers are checked before exit codes, since e.g. pytest exits
+	// with the same code when interrupted and on collection errors.
+	switch {
+	case markers.timedOut:
+		return StatusTimedOut
+	case markers.interrupted:
+		return StatusInterrupted
+	case markers.setupFailed:
+		return StatusSetupFailed
+	case exitCode == exitCodeSIGINT, exitCode == exitCodeSIGKILL, exitCode == exitCodeSIGTERM,
+		pytestExit(pytestExitInterrupted):
+		return StatusInterrupted
+	case pytestExit(pytestExitInternalError), pytestExit(pytestExitUsageError):
+		return StatusSetupFailed
+	case pytestExit(pytestExitNoTests):
+		return StatusNoTestsSelected
+	}
+
+	if testSuite == nil {
+		switch {
+		case hasExitCode && exitCode != 0:
+			return StatusSetupFailed
+		case markers.noTests:
+			return StatusNoTestsSelected
+		case !markers.started:
+			return StatusNotStarted
+		}
+		// The run started, but neither left results nor says how it ended.
+		return StatusInterrupted
+	}
+
+	if testSuite.Incomplete && !markers.finished {
+		return StatusInterrupted
+	}
+	if testSuite.hasFailedSetupNode() || testSuite.SetupFailure {
+		return StatusSetupFailed
+	}
+	if testSuite.Failures+testSuite.Errors > 0 {
+		return StatusCompletedWithFailures
+	}
+	if markers.noTests || max(testSuite.Tests-testSuite.Skipped-testSuite.Disabled, 0) == 0 {
+		return StatusNoTestsSelected
+	}
+	if hasExitCode && exitCode != 0 {
+		return StatusCompletedWithFailures
+	}
+	return StatusCompleted
+}
+
+// hasFailedSetupNode reports whether a BeforeSuite node of the suite failed.
+func (ts TestSuite) hasFailedSetupNode() bool {
+	for _, testCase := range ts.TestCases {
+		if testCase.IsFailed() && (testCase.Name == "[BeforeSuite]" || testCase.Name == "[SynchronizedBeforeSuite]") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package junit
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func TestDeriveStatus(t *testing.T) {
+	passed := TestSuite{Tests: 3, TestCases: []TestCase{{Name: "a"}, {Name: "b"}, {Name: "c"}}}
+	failed := TestSuite{Tests: 2, Failures: 1, TestCases: []TestCase{{Name: "a", Failure: &Outcome{}}, {Name: "b"}}}
+	beforeSuite := TestSuite{Tests: 1, Failures: 1, TestCases: []TestCase{{Name: "[BeforeSuite]", Failure: &Outcome{}}}}
+	allSkipped := TestSuite{Tests: 2, Skipped: 2, TestCases: []TestCase{{Name: "a", Skipped: &Outcome{}}, {Name: "b", Skipped: &Outcome{}}}}
+	incomplete := TestSuite{Tests: 1, Incomplete: true, TestCases: []TestCase{{Name: "a"}}}
+
+	tests := []struct {
+		name        string
+		testSuite   *TestSuite
+		exitCode    int
+		hasExitCode bool
+		markers     logMarkers
+		expected    Status
+	}{
+		{"all passed", &passed, 0, true, logMarkers{started: true, finished: true}, StatusCompleted},
+		{"all passed without exit code", &passed, 0, false, logMarkers{}, StatusCompleted},
+		{"failures", &failed, 1, true, logMarkers{started: true, finished: true}, StatusCompletedWithFailures},
+		{"ginkgo suite timeout", &failed, 1, true, logMarkers{started: true, finished: true, timedOut: true}, StatusTimedOut},
+		{"sigterm exit code", &failed, 143, true, logMarkers{started: true}, StatusInterrupted},
+		{"interrupted by user", nil, 1, true, logMarkers{started: true, interrupted: true}, StatusInterrupted},
+		{"killed without results", nil, 0, false, logMarkers{started: true}, StatusInterrupted},
+		{"recovered from log", &incomplete, 0, false, logMarkers{started: true}, StatusInterrupted},
+		{"recovered from finished log", &incomplete, 0, true, logMarkers{started: true, finished: true}, StatusCompleted},
+		{"failed BeforeSuite node", &beforeSuite, 1, true, logMarkers{started: true}, StatusSetupFailed},
+		{"BeforeSuite marker without results", nil, 1, true, logMarkers{started: true, setupFailed: true}, StatusSetupFailed},
+		{"non-zero exit without results", nil, 1, true, logMarkers{}, StatusSetupFailed},
+		{"pytest collection errors", nil, 2, true, logMarkers{started: true, setupFailed: true, pytest: true}, StatusSetupFailed},
+		{"pytest interrupted", &passed, 2, true, logMarkers{started: true, pytest: true}, StatusInterrupted},
+		{"pytest internal error", nil, 3, true, logMarkers{started: true, pytest: true}, StatusSetupFailed},
+		{"pytest exit 5", nil, 5, true, logMarkers{started: true, pytest: true}, StatusNoTestsSelected},
+		{"ginkgo exit 5 is not pytest", &failed, 5, true, logMarkers{started: true}, StatusCompletedWithFailures},
+		{"all tests skipped", &allSkipped, 0, true, logMarkers{started: true, finished: true}, StatusNoTestsSelected},
+		{"no tests marker", nil, 0, true, logMarkers{started: true, noTests: true}, StatusNoTestsSelected},
+		{"nothing at all", nil, 0, false, logMarkers{}, StatusNotStarted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := deriveStatus(tt.testSuite, tt.exitCode, tt.hasExitCode, tt.markers); status != tt.expected {
+				t.Errorf("expected status %q, got %q", tt.expected, status)
+			}
+		})
+	}
+}
+
+func TestReadLogMarkers(t *testing.T) {
+	tests := map[string]struct {
+		log      string
+		expected logMarkers
+	}{
+		"ginkgo timeout": {
+			log: "Will run 120 of 1834 specs\n" +
+				"[TIMEDOUT] A suite timeout occurred\n" +
+				"Ran 37 of 1834 Specs in 25200.012 seconds\n" +
+				"FAIL! - Suite Timeout Elapsed -- 36 Passed | 1 Failed | 0 Pending | 1797 Skipped\n",
+			expected: logMarkers{started: true, finished: true, timedOut: true},
+		},
+		"ginkgo interrupted": {
+			log:      "Will run 120 of 1834 specs\n  [INTERRUPTED] Interrupted by User\n",
+			expected: logMarkers{started: true, interrupted: true},
+		},
+		"ginkgo BeforeSuite failure": {
+			log:      "Will run 120 of 1834 specs\n[BeforeSuite] [FAILED] [12.004 seconds]\n",
+			expected: logMarkers{started: true, setupFailed: true},
+		},
+		"ginkgo filtered to zero": {
+			log:      "Will run 0 of 1834 specs\nRan 0 of 1834 Specs in 0.001 seconds\n",
+			expected: logMarkers{started: true, finished: true, noTests: true},
+		},
+		"pytest passed": {
+			log: "============================= test session starts ==============================\n" +
+				"collected 312 items / 308 deselected / 4 selected\n" +
+				"================ 4 passed, 308 deselected in 312.12s (0:05:12) =================\n",
+			expected: logMarkers{started: true, finished: true, pytest: true},
+		},
+		"pytest collection error": {
+			log: "============================= test session starts ==============================\n" +
+				"_____________________ ERROR collecting tests/virt/test_a.py _____________________\n" +
+				"!!!!!!!!!!!!!!!!!!!! Interrupted: 1 error during collection !!!!!!!!!!!!!!!!!!!!!\n",
+			expected: logMarkers{started: true, setupFailed: true, pytest: true},
+		},
+		"pytest all deselected": {
+			log: "============================= test session starts ==============================\n" +
+				"collected 312 items / 312 deselected / 0 selected\n" +
+				"=========================== 312 deselected in 1.20s ============================\n",
+			expected: logMarkers{started: true, finished: true, noTests: true, pytest: true},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fileName := path.Join(t.TempDir(), "suite-log.txt")
+			if err := os.WriteFile(fileName, []byte(tt.log), 0644); err != nil {
+				t.Fatalf("failed to write log: %v", err)
+			}
+
+			if markers := readLogMarkers(fileName); markers != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, markers)
+			}
+		})
+	}
+}
+
+func TestNewResultMapSetsSuiteStatus(t *testing.T) {
+	dir := generateResDir(t, map[string]string{
+		"compute": `<testsuite tests="2" failures="1"><testcase name="a"><failure/></testcase><testcase name="b"/></testsuite>`,
+		"network": `<testsuite tests="1"><testcase name="a"/></testsuite>`,
+		"ssp":     "",
+		"storage": "",
+	})
+
+	files := map[string]string{
+		"compute/.exit_code":      "1\n",
+		"network/.exit_code":      "0\n",
+		"ssp/ssp-log.txt":         "Will run 12 of 300 specs\n[BeforeSuite] [FAILED] [1.2 seconds]\n",
+		"ssp/.exit_code":          "1\n",
+		"storage/storage-log.txt": "Will run 0 of 1834 specs\nRan 0 of 1834 Specs in 0.001 seconds\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(path.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	result, err := NewResultMap(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]Status{
+		"compute": StatusCompletedWithFailures,
+		"network": StatusCompleted,
+		"ssp":     StatusSetupFailed,
+		"storage": StatusNoTestsSelected,
+	}
+	for sig, status := range expected {
+		if result[sig].Status != status {
+			t.Errorf("expected %s to be %q, got %q", sig, status, result[sig].Status)
+		}
+	}
+	if !result["ssp"].SetupFailure {
+		t.Error("expected ssp to be a setup failure")
+	}
+	if result["storage"].SetupFailure {
+		t.Error("expected storage not to be a setup failure")
+	}
+}
@@ -0,0 +1,387 @@
+package junit
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxTextLength is the maximum number of bytes kept from the text content of
+// <system-out>, <failure>, <error> and <skipped> elements, and from the
+// captured output and failure message of Ginkgo specs. Ginkgo and pytest can
+// write megabytes of output per test case, and the parser runs with a tight
+// memory limit, so anything beyond this is dropped from the results. Note
+// that the decoders still buffer a whole text token or JSON value while
+// reading it, so this bounds what is kept rather than the peak memory.
+const maxTextLength = 4 * 1024
+
+// decodeTestSuites reads a JUnit document token by token, without loading the
+// whole document into memory. Both a <testsuites> root and a bare <testsuite>
+// root are accepted. Nested <testsuite> elements are returned as separate
+// suites, and only the leaf suites are counted, see decodeTestSuite.
+//
+// The duration of the whole document is returned as well: the time of the
+// <testsuites> root, or else the longest time of its top-level <testsuite>
+// elements, which include the time of their nested suites.
+func decodeTestSuites(reader io.Reader) ([]TestSuite, float64, error) {
+	decoder := xml.NewDecoder(reader)
+
+	var (
+		suites    []TestSuite
+		rootTime  float64
+		suiteTime float64
+		foundRoot bool
+	)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return suites, rootTime, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "testsuites":
+			foundRoot = true
+			for _, attr := range start.Attr {
+				if attr.Name.Local != "time" {
+					continue
+				}
+				if rootTime, err = parseFloatAttr(attr); err != nil {
+					return suites, 0, err
+				}
+			}
+		case "testsuite":
+			foundRoot = true
+			var header TestSuite
+			if err := decodeSuiteAttrs(&header, start.Attr); err != nil {
+				return suites, 0, err
+			}
+			suiteTime = max(suiteTime, header.Time)
+			decoded, err := decodeTestSuite(decoder, start)
+			suites = append(suites, decoded...)
+			if err != nil {
+				return suites, rootTime, err
+			}
+		default:
+			if !foundRoot {
+				return nil, 0, fmt.Errorf("expected element type <testsuites> or <testsuite> but have <%s>", start.Name.Local)
+			}
+			if err := decoder.Skip(); err != nil {
+				return suites, rootTime, err
+			}
+		}
+	}
+
+	if !foundRoot {
+		return nil, 0, errors.New("no <testsuites> or <testsuite> element found")
+	}
+
+	if rootTime == 0 {
+		rootTime = suiteTime
+	}
+	return suites, rootTime, nil
+}
+
+// decodeTestSuite decodes a <testsuite> element whose start token was already
+// consumed. The suite itself is the first element of the returned slice,
+// followed by any nested suites. On error, the returned suites hold what was
+// decoded so far.
+//
+// The header counts of a suite with nested suites already include theirs, so
+// such a suite is only returned when it has test cases of its own, with its
+// counts taken from those test cases; its properties are passed on to the
+// nested suites that do not define them.
+func decodeTestSuite(decoder *xml.Decoder, start xml.StartElement) ([]TestSuite, error) {
+	testSuite := TestSuite{XMLName: start.Name}
+	if err := decodeSuiteAttrs(&testSuite, start.Attr); err != nil {
+		return nil, err
+	}
+
+	var nested []TestSuite
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return append([]TestSuite{testSuite}, nested...), err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "testcase":
+				testCase, err := decodeTestCase(decoder, t)
+				if err != nil {
+					return append([]TestSuite{testSuite}, nested...), err
+				}
+				testSuite.TestCases = append(testSuite.TestCases, testCase)
+			case "testsuite":
+				decoded, err := decodeTestSuite(decoder, t)
+				nested = append(nested, decoded...)
+				if err != nil {
+					return append([]TestSuite{testSuite}, nested...), err
+				}
+			case "properties":
+				properties, err := decodeProperties(decoder)
+				testSuite.Properties = append(testSuite.Properties, properties...)
+				if err != nil {
+					return append([]TestSuite{testSuite}, nested...), err
+				}
+			default:
+				if err := decoder.Skip(); err != nil {
+					return append([]TestSuite{testSuite}, nested...), err
+				}
+			}
+		case xml.EndElement:
+			if len(nested) == 0 {
+				return []TestSuite{testSuite}, nil
+			}
+			return withoutParentCounts(testSuite, nested), nil
+		}
+	}
+}
+
+// withoutParentCounts returns the nested suites of a parent suite, preceded by
+// the parent when it has test cases of its own, so that the tests of the
+// nested suites are not counted twice.
+func withoutParentCounts(parent TestSuite, nested []TestSuite) []TestSuite {
+	for i := range nested {
+		for _, property := range parent.Properties {
+			if _, ok := nested[i].Property(property.Name); !ok {
+				nested[i].Properties = append(nested[i].Properties, property)
+			}
+		}
+	}
+
+	if len(parent.TestCases) == 0 {
+		return nested
+	}
+
+	parent.Tests, parent.Failures, parent.Errors, parent.Skipped, parent.Disabled, parent.Time = 0, 0, 0, 0, 0, 0
+	for _, testCase := range parent.TestCases {
+		parent.Tests++
+		parent.Time += testCase.Time
+		switch {
+		case testCase.Failure != nil:
+			parent.Failures++
+		case testCase.Error != nil:
+			parent.Errors++
+		case testCase.Skipped != nil:
+			parent.Skipped++
+		}
+	}
+	return append([]TestSuite{parent}, nested...)
+}
+
+// capText returns text cut down to maxTextLength bytes.
+func capText(text string) string {
+	if len(text) > maxTextLength {
+		return text[:maxTextLength]
+	}
+	return text
+}
+
+func decodeSuiteAttrs(testSuite *TestSuite, attrs []xml.Attr) error {
+	var err error
+	for _, attr := range attrs {
+		switch attr.Name.Local {
+		case "name":
+			testSuite.Name = attr.Value
+		case "tests":
+			testSuite.Tests, err = parseIntAttr(attr)
+		case "failures":
+			testSuite.Failures, err = parseIntAttr(attr)
+		case "errors":
+			testSuite.Errors, err = parseIntAttr(attr)
+		case "skipped":
+			testSuite.Skipped, err = parseIntAttr(attr)
+		case "disabled":
+			testSuite.Disabled, err = parseIntAttr(attr)
+		case "time":
+			testSuite.Time, err = parseFloatAttr(attr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeTestCase decodes a <testcase> element whose start token was already consumed.
+func decodeTestCase(decoder *xml.Decoder, start xml.StartElement) (TestCase, error) {
+	testCase := TestCase{}
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "name":
+			testCase.Name = attr.Value
+		case "classname":
+			testCase.Classname = attr.Value
+		case "time":
+			seconds, err := parseFloatAttr(attr)
+			if err != nil {
+				return testCase, err
+			}
+			testCase.Time = seconds
+		}
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return testCase, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "failure":
+				testCase.Failure, err = decodeOutcome(decoder, t)
+			case "error":
+				testCase.Error, err = decodeOutcome(decoder, t)
+			case "skipped":
+				testCase.Skipped, err = decodeOutcome(decoder, t)
+			case "system-out":
+				testCase.SystemOut, err = readCappedText(decoder)
+			case "properties":
+				var properties []Property
+				properties, err = decodeProperties(decoder)
+				testCase.Properties = append(testCase.Properties, properties...)
+			default:
+				err = decoder.Skip()
+			}
+			if err != nil {
+				return testCase, err
+			}
+		case xml.EndElement:
+			testCase.Metadata = ParseMetadata(testCase.Name, PropertyLabels(testCase.Properties))
+			return testCase, nil
+		}
+	}
+}
+
+// decodeOutcome decodes a <failure>, <error> or <skipped> element whose start token was already consumed.
+func decodeOutcome(decoder *xml.Decoder, start xml.StartElement) (*Outcome, error) {
+	outcome := &Outcome{}
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "message":
+			outcome.Message = attr.Value
+		case "type":
+			outcome.Type = attr.Value
+		}
+	}
+
+	text, err := readCappedText(decoder)
+	if err != nil {
+		return nil, err
+	}
+	outcome.Text = text
+
+	return outcome, nil
+}
+
+// decodeProperties decodes a <properties> element whose start token was
+// already consumed. A property value is taken from the value attribute, or
+// from the element body when the attribute is missing.
+func decodeProperties(decoder *xml.Decoder) ([]Property, error) {
+	var properties []Property
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return properties, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "property" {
+				if err := decoder.Skip(); err != nil {
+					return properties, err
+				}
+				continue
+			}
+
+			property := Property{}
+			hasValue := false
+			for _, attr := range t.Attr {
+				switch attr.Name.Local {
+				case "name":
+					property.Name = attr.Value
+				case "value":
+					property.Value = attr.Value
+					hasValue = true
+				}
+			}
+
+			text, err := readCappedText(decoder)
+			if err != nil {
+				return properties, err
+			}
+			if !hasValue {
+				property.Value = strings.TrimSpace(text)
+			}
+
+			if property.Name != "" {
+				properties = append(properties, property)
+			}
+		case xml.EndElement:
+			return properties, nil
+		}
+	}
+}
+
+// readCappedText reads the character data of the current element up to its
+// end token, keeping at most maxTextLength bytes. Child elements are skipped.
+func readCappedText(decoder *xml.Decoder) (string, error) {
+	sb := strings.Builder{}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return sb.String(), err
+		}
+
+		switch t := token.(type) {
+		case xml.CharData:
+			if remaining := maxTextLength - sb.Len(); remaining > 0 {
+				sb.Write(t[:min(len(t), remaining)])
+			}
+		case xml.StartElement:
+			if err := decoder.Skip(); err != nil {
+				return sb.String(), err
+			}
+		case xml.EndElement:
+			return sb.String(), nil
+		}
+	}
+}
+
+func parseIntAttr(attr xml.Attr) (int, error) {
+	value := strings.TrimSpace(attr.Value)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s attribute %q: %w", attr.Name.Local, attr.Value, err)
+	}
+	return n, nil
+}
+
+func parseFloatAttr(attr xml.Attr) (float64, error) {
+	value := strings.TrimSpace(attr.Value)
+	if value == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s attribute %q: %w", attr.Name.Local, attr.Value, err)
+	}
+	return f, nil
+}
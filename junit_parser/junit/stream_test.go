@@ -0,0 +1,301 @@
+package junit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestDecodeTestSuitesCapsSystemOut(t *testing.T) {
+	largeOutput := strings.Repeat("a", 3*maxTextLength)
+	data := fmt.Sprintf(`<testsuite name="suite" tests="1" failures="1">
+  <testcase name="test1" classname="class1" time="1.5">
+    <failure message="boom" type="Failure">%s</failure>
+    <system-out>%s</system-out>
+    <system-err>%s</system-err>
+  </testcase>
+</testsuite>`, largeOutput, largeOutput, largeOutput)
+
+	suites, _, err := decodeTestSuites(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(suites) != 1 || len(suites[0].TestCases) != 1 {
+		t.Fatalf("expected 1 suite with 1 test case, got %+v", suites)
+	}
+
+	testCase := suites[0].TestCases[0]
+	if len(testCase.SystemOut) != maxTextLength {
+		t.Errorf("expected system-out to be capped at %d bytes, got %d", maxTextLength, len(testCase.SystemOut))
+	}
+	if len(testCase.Failure.Text) != maxTextLength {
+		t.Errorf("expected failure text to be capped at %d bytes, got %d", maxTextLength, len(testCase.Failure.Text))
+	}
+	if testCase.Failure.Message != "boom" || testCase.Failure.Type != "Failure" {
+		t.Errorf("unexpected failure attributes: %+v", testCase.Failure)
+	}
+	if testCase.Time != 1.5 {
+		t.Errorf("expected time 1.5, got %v", testCase.Time)
+	}
+}
+
+func TestDecodeTestSuitesHandlesBothRoots(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		expectedSuites int
+	}{
+		{
+			name:           "bare testsuite root",
+			data:           `<?xml version="1.0"?><testsuite name="s1" tests="1"><testcase name="t1"/></testsuite>`,
+			expectedSuites: 1,
+		},
+		{
+			name:           "testsuites root",
+			data:           `<testsuites><testsuite name="s1" tests="1"><properties><property name="a" value="b"/></properties><testcase name="t1"/></testsuite><testsuite name="s2"/></testsuites>`,
+			expectedSuites: 2,
+		},
+		{
+			name:           "empty testsuites root",
+			data:           `<testsuites></testsuites>`,
+			expectedSuites: 0,
+		},
+		{
+			name:           "nested testsuite",
+			data:           `<testsuite name="outer"><testsuite name="inner"><testcase name="t1"/></testsuite></testsuite>`,
+			expectedSuites: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suites, _, err := decodeTestSuites(strings.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(suites) != tt.expectedSuites {
+				t.Errorf("expected %d suites, got %d", tt.expectedSuites, len(suites))
+			}
+		})
+	}
+}
+
+func TestDecodeTestSuitesRejectsInvalidDocuments(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "unknown root", data: `<invalid></invalid>`},
+		{name: "empty document", data: ``},
+		{name: "truncated document", data: `<testsuite name="s1"><testcase name="t1">`},
+		{name: "invalid counter", data: `<testsuite name="s1" tests="many"></testsuite>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := decodeTestSuites(strings.NewReader(tt.data)); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+// generateLargeJUnit builds a synthetic JUnit document with the given number
+// of test cases, each carrying outputSize bytes of <system-out>.
+func generateLargeJUnit(testCases, outputSize int) []byte {
+	output := strings.Repeat("W0422 09:56:43.898494 warnings.go:70] some noisy log line\n", outputSize/58+1)[:outputSize]
+
+	buf := bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	buf.WriteString(fmt.Sprintf(`<testsuites><testsuite name="Tests Suite" tests="%d" failures="%d">`, testCases, testCases/10))
+	for i := 0; i < testCases; i++ {
+		buf.WriteString(fmt.Sprintf(`<testcase name="[sig-compute] test %d" classname="Tests Suite" time="12.5">`, i))
+		if i%10 == 0 {
+			buf.WriteString(`<failure type="Failure">tests/migration/migration.go:1168&#xA;Timed out after 180.001s.</failure>`)
+		}
+		buf.WriteString("<system-out>")
+		buf.WriteString(output)
+		buf.WriteString("</system-out></testcase>")
+	}
+	buf.WriteString("</testsuite></testsuites>")
+
+	return buf.Bytes()
+}
+
+func BenchmarkReadTestSuiteLargeSystemOut(b *testing.B) {
+	data := generateLargeJUnit(2000, 64*1024)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		testSuite, err := readTestSuite(bytes.NewReader(data))
+		if err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		if len(testSuite.TestCases) != 2000 {
+			b.Fatalf("expected 2000 test cases, got %d", len(testSuite.TestCases))
+		}
+	}
+}
+
+func BenchmarkReadTestSuiteManyTestCases(b *testing.B) {
+	data := generateLargeJUnit(20000, 256)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := readTestSuite(bytes.NewReader(data)); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkReadTestSuiteKubevirt(b *testing.B) {
+	data, err := os.ReadFile(path.Join("testdata", "results", "kubevirt", junitFileName))
+	if err != nil {
+		b.Fatalf("failed to read fixture: %v", err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := readTestSuite(bytes.NewReader(data)); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestDecodeTestSuitesReadsProperties(t *testing.T) {
+	testSuite, err := readTestSuite(strings.NewReader(`<testsuite name="pytest" tests="1">
+  <properties>
+    <property name="pytest_version" value="8.3.4"/>
+    <property name="Python">3.12.1</property>
+    <property value="no name"/>
+  </properties>
+  <testcase name="test_a" classname="tests.virt.test_a">
+    <properties>
+      <property name="polarion-id" value="CNV-1234"/>
+    </properties>
+  </testcase>
+</testsuite>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Property{{Name: "pytest_version", Value: "8.3.4"}, {Name: "Python", Value: "3.12.1"}}
+	if len(testSuite.Properties) != len(expected) {
+		t.Fatalf("expected %d suite properties, got %v", len(expected), testSuite.Properties)
+	}
+	for i, property := range expected {
+		if testSuite.Properties[i] != property {
+			t.Errorf("expected property %v, got %v", property, testSuite.Properties[i])
+		}
+	}
+
+	if value, ok := testSuite.Property("pytest_version"); !ok || value != "8.3.4" {
+		t.Errorf("expected pytest_version 8.3.4, got %q", value)
+	}
+	if _, ok := testSuite.Property("missing"); ok {
+		t.Error("expected missing property to not be found")
+	}
+
+	caseProperties := testSuite.TestCases[0].Properties
+	if len(caseProperties) != 1 || caseProperties[0] != (Property{Name: "polarion-id", Value: "CNV-1234"}) {
+		t.Errorf("unexpected test case properties: %v", caseProperties)
+	}
+}
+
+func TestReadOneFileReadsGinkgoSuiteConfig(t *testing.T) {
+	testSuite, err := readOneFile(path.Join("testdata", "results", "ssp", "junit.results.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, expected := range map[string]string{
+		"RandomSeed":  "1745426677",
+		"LabelFilter": "!HIGHLY_AVAILABLE_CLUSTER",
+		"FocusFiles":  "",
+	} {
+		if value, ok := testSuite.Property(name); !ok || value != expected {
+			t.Errorf("expected %s to be %q, got %q (found: %v)", name, expected, value, ok)
+		}
+	}
+}
+
+func TestReadTestSuiteCountsOnlyLeafSuites(t *testing.T) {
+	data := `<testsuites>
+  <testsuite name="outer" tests="4" failures="2" time="10">
+    <properties><property name="platform" value="linux"/></properties>
+    <testcase name="own" time="1"><failure message="boom"/></testcase>
+    <testsuite name="inner-1" tests="2" failures="1" time="5">
+      <testcase name="t1" time="2"><failure message="boom"/></testcase>
+      <testcase name="t2" time="3"/>
+    </testsuite>
+    <testsuite name="inner-2" tests="1" time="4">
+      <testcase name="t3" time="4"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+	testSuite, err := readTestSuite(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Tests != 4 || testSuite.Failures != 2 || testSuite.Time != 10 {
+		t.Errorf("expected the tests to be counted once, got tests=%d failures=%d time=%v", testSuite.Tests, testSuite.Failures, testSuite.Time)
+	}
+	if len(testSuite.SubSuites) != 3 || testSuite.SubSuites[0].Tests != 1 {
+		t.Fatalf("expected the outer suite to only count its own test, got %+v", testSuite.SubSuites)
+	}
+	if value, ok := testSuite.SubSuites[2].Property("platform"); !ok || value != "linux" {
+		t.Errorf("expected the nested suites to inherit the outer properties, got %q (found: %v)", value, ok)
+	}
+}
+
+func TestReadTestSuiteTakesTheDurationOfTheDocument(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		expectedTime float64
+	}{
+		{
+			name: "testsuites time",
+			data: `<testsuites time="30">
+  <testsuite name="gw0" tests="1" time="20"><testcase name="t1" time="20"/></testsuite>
+  <testsuite name="gw1" tests="1" time="25"><testcase name="t2" time="25"/></testsuite>
+</testsuites>`,
+			expectedTime: 30,
+		},
+		{
+			name: "parallel suites",
+			data: `<testsuites>
+  <testsuite name="gw0" tests="1" time="20"><testcase name="t1" time="20"/></testsuite>
+  <testsuite name="gw1" tests="1" time="25"><testcase name="t2" time="25"/></testsuite>
+</testsuites>`,
+			expectedTime: 25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSuite, err := readTestSuite(strings.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if testSuite.Time != tt.expectedTime {
+				t.Errorf("expected time %v, got %v", tt.expectedTime, testSuite.Time)
+			}
+			if len(testSuite.SubSuites) != 2 || testSuite.SubSuites[0].Time != 20 || testSuite.SubSuites[1].Time != 25 {
+				t.Errorf("expected the sub-suites to keep their own times, got %+v", testSuite.SubSuites)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package junit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// suiteLogSuffix is appended to the suite name to get the name of the log
+// file the runner scripts tee the test output to, e.g. "compute-log.txt".
+const suiteLogSuffix = "-log.txt"
+
+var (
+	// "Will run 120 of 1834 specs"
+	ginkgoWillRunPattern = regexp.MustCompile(`Will run (\d+) of \d+ specs`)
+	// "collected 300 items / 250 deselected / 50 selected"
+	pytestSelectedPattern = regexp.MustCompile(`collected \d+ items / \d+ deselected / (\d+) selected`)
+	// "collected 50 items"
+	pytestCollectedPattern = regexp.MustCompile(`collected (\d+) items$`)
+	// "• [12.345 seconds]", "• [FAILED] [180.101 seconds]"
+	ginkgoCompletedPattern = regexp.MustCompile(`^•(?: \[(FAILED|PANICKED|TIMEDOUT|INTERRUPTED|ABORTED)\])?`)
+	// "S [SKIPPED] [0.000 seconds]", "P [PENDING]"
+	ginkgoSkippedPattern = regexp.MustCompile(`^[SP] \[(SKIPPED|PENDING)\]`)
+	// "• [FLAKEY TEST - TOOK 2 ATTEMPTS TO PASS] [12.345 seconds]"
+	ginkgoFlakyPattern    = regexp.MustCompile(`\[FLAKEY TEST - TOOK (\d+) ATTEMPTS TO PASS\]`)
+	ginkgoDurationPattern = regexp.MustCompile(`\[([\d.]+) seconds\]`)
+	// "[FAILED] Timed out after 180.001s."
+	ginkgoReasonPattern = regexp.MustCompile(`^\[(FAILED|PANICKED|TIMEDOUT|INTERRUPTED|ABORTED)\] (.+)`)
+	// "TEST: tests/virt/test_vm.py::TestVM::test_start STATUS: PASSED"
+	pytestResultPattern = regexp.MustCompile(`^TEST:\s*(.+?)\s+STATUS:\s*([A-Z]+)`)
+)
+
+// readSuiteLogFile reconstructs the results of a suite from the console
+// output of its run, for when the result files are missing or truncated,
+// e.g. because the Job was killed or the suite timed out. Only the tests
+// that completed are known, so the suite is marked as incomplete.
+func readSuiteLogFile(fileName string) (TestSuite, error) {
+	logFile, err := os.Open(fileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return TestSuite{}, fmt.Errorf("log file %q does not exist", fileName)
+		}
+		return TestSuite{}, fmt.Errorf("unknow error while opening %s; %w", fileName, err)
+	}
+
+	defer logFile.Close()
+
+	testSuite, err := readSuiteLog(logFile)
+	if err != nil {
+		return testSuite, fmt.Errorf("failed to recover results from log file: %s; %v", fileName, err)
+	}
+
+	return testSuite, nil
+}
+
+// suiteLogParser holds the state of a Ginkgo spec whose completion line was
+// seen, while its name and failure reason are read from the lines below it.
+type suiteLogParser struct {
+	testSuite  TestSuite
+	needName   bool
+	needReason bool
+}
+
+func readSuiteLog(reader io.Reader) (TestSuite, error) {
+	p := &suiteLogParser{testSuite: TestSuite{Incomplete: true}}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		p.parseLine(strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return TestSuite{}, err
+	}
+
+	if len(p.testSuite.TestCases) == 0 {
+		return TestSuite{}, errors.New("no completed tests found")
+	}
+
+	return p.testSuite, nil
+}
+
+func (p *suiteLogParser) parseLine(line string) {
+	trimmed := strings.TrimSpace(line)
+
+	if p.testSuite.Expected == 0 {
+		for _, pattern := range []*regexp.Regexp{ginkgoWillRunPattern, pytestSelectedPattern, pytestCollectedPattern} {
+			if match := pattern.FindStringSubmatch(line); match != nil {
+				p.testSuite.Expected, _ = strconv.Atoi(match[1])
+				return
+			}
+		}
+	}
+
+	if match := ginkgoCompletedPattern.FindStringSubmatch(line); match != nil {
+		// Succinct output prints one bullet per passed spec, without names.
+		if strings.Trim(trimmed, "•") == "" {
+			for range strings.Count(trimmed, "•") {
+				p.addTestCase(TestCase{})
+			}
+			p.needName, p.needReason = false, false
+			return
+		}
+
+		testCase := TestCase{Time: parseDuration(line)}
+		if flaky := ginkgoFlakyPattern.FindStringSubmatch(line); flaky != nil {
+			testCase.Attempts, _ = strconv.Atoi(flaky[1])
+		}
+		if match[1] != "" {
+			outcome := &Outcome{Type: strings.ToLower(match[1])}
+			if match[1] == "FAILED" || match[1] == "TIMEDOUT" {
+				testCase.Failure = outcome
+			} else {
+				testCase.Error = outcome
+			}
+		}
+		p.addTestCase(testCase)
+		p.needName, p.needReason = true, testCase.IsFailed()
+		return
+	}
+
+	if match := ginkgoSkippedPattern.FindStringSubmatch(line); match != nil {
+		p.addTestCase(TestCase{
+			Time:    parseDuration(line),
+			Skipped: &Outcome{Type: strings.ToLower(match[1])},
+		})
+		p.needName, p.needReason = true, false
+		return
+	}
+
+	if match := pytestResultPattern.FindStringSubmatch(line); match != nil {
+		p.addTestCase(pytestTestCase(match[1], match[2]))
+		p.needName, p.needReason = false, false
+		return
+	}
+
+	if strings.HasPrefix(trimmed, "---") {
+		p.needName, p.needReason = false, false
+		return
+	}
+
+	if !p.needName && !p.needReason {
+		return
+	}
+
+	last := &p.testSuite.TestCases[len(p.testSuite.TestCases)-1]
+	if p.needName && trimmed != "" {
+		p.needName = false
+		if !strings.HasPrefix(trimmed, "/") {
+			last.Name = strings.Replace(trimmed, " [It] ", " ", 1)
+			last.Classname = "Tests Suite"
+			last.Metadata = ParseMetadata(last.Name, nil)
+		}
+		return
+	}
+
+	if p.needReason {
+		if match := ginkgoReasonPattern.FindStringSubmatch(trimmed); match != nil {
+			outcome := last.Failure
+			if outcome == nil {
+				outcome = last.Error
+			}
+			outcome.Message = match[2]
+			p.needReason = false
+		}
+	}
+}
+
+// addTestCase appends a completed test to the suite and updates its counts.
+// Tests whose name is not found in the log are named after their position.
+func (p *suiteLogParser) addTestCase(testCase TestCase) {
+	ts := &p.testSuite
+	if testCase.Name == "" {
+		testCase.Name = fmt.Sprintf("unnamed test #%d", len(ts.TestCases)+1)
+	}
+
+	ts.Tests++
+	switch {
+	case testCase.Failure != nil:
+		ts.Failures++
+	case testCase.Error != nil:
+		ts.Errors++
+	case testCase.Skipped != nil:
+		ts.Skipped++
+	}
+	ts.Time += testCase.Time
+	ts.TestCases = append(ts.TestCases, testCase)
+}
+
+// pytestTestCase builds a test case from a pytest node ID and status, naming
+// it the way pytest's JUnit report does, e.g.
+// "tests/virt/test_vm.py::TestVM::test_start" → "tests.virt.test_vm.TestVM" / "test_start".
+func pytestTestCase(nodeID, status string) TestCase {
+	parts := strings.Split(nodeID, "::")
+	parts[0] = strings.ReplaceAll(strings.TrimSuffix(parts[0], ".py"), "/", ".")
+
+	testCase := TestCase{Name: parts[len(parts)-1]}
+	if len(parts) > 1 {
+		testCase.Classname = strings.Join(parts[:len(parts)-1], ".")
+	}
+
+	switch status {
+	case "FAILED":
+		testCase.Failure = &Outcome{Type: "failed"}
+	case "ERROR":
+		testCase.Error = &Outcome{Type: "error"}
+	case "SKIPPED", "XFAIL":
+		testCase.Skipped = &Outcome{Type: strings.ToLower(status)}
+	}
+
+	return testCase
+}
+
+// parseDuration returns the "[12.345 seconds]" duration of a Ginkgo spec
+// completion line, or zero when it has none.
+func parseDuration(line string) float64 {
+	match := ginkgoDurationPattern.FindStringSubmatch(line)
+	if match == nil {
+		return 0
+	}
+	seconds, _ := strconv.ParseFloat(match[1], 64)
+	return seconds
+}
@@ -0,0 +1,130 @@
+package junit
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestReadSuiteLogFileRecoversGinkgoSpecs(t *testing.T) {
+	testSuite, err := readSuiteLogFile(path.Join("testdata", "logs", "compute-log.txt"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !testSuite.Incomplete {
+		t.Error("expected the suite to be marked as incomplete")
+	}
+	if testSuite.Expected != 5 {
+		t.Errorf("expected 5 expected tests, got %d", testSuite.Expected)
+	}
+	if testSuite.Tests != 3 || testSuite.Failures != 1 || testSuite.Skipped != 1 {
+		t.Errorf("expected 3 tests, 1 failure and 1 skipped, got %d, %d and %d",
+			testSuite.Tests, testSuite.Failures, testSuite.Skipped)
+	}
+	if len(testSuite.TestCases) != 3 {
+		t.Fatalf("expected 3 test cases, got %d", len(testSuite.TestCases))
+	}
+
+	failed := testSuite.TestCases[0]
+	expectedName := "[rfe_id:393][crit:high][vendor:cnv-qe@redhat.com][level:system][sig-compute] VM Live Migration Starting a VirtualMachineInstance [test_id:1783]should be successfully migrated multiple times with cloud-init disk"
+	if failed.Name != expectedName {
+		t.Errorf("expected name\n%s\ngot\n%s", expectedName, failed.Name)
+	}
+	if failed.FailureReason() != "Timed out after 180.001s." {
+		t.Errorf("unexpected failure reason: '%s'", failed.FailureReason())
+	}
+	if failed.Time != 180.101 {
+		t.Errorf("expected time 180.101, got %v", failed.Time)
+	}
+	if failed.Metadata.Crit != "high" || len(failed.Metadata.TestIDs) != 1 || failed.Metadata.TestIDs[0] != "1783" {
+		t.Errorf("unexpected metadata: %+v", failed.Metadata)
+	}
+
+	flaky := testSuite.TestCases[1]
+	if !flaky.IsFlaky() || flaky.Attempts != 2 || flaky.Time != 45.2 {
+		t.Errorf("expected a flaky test with 2 attempts taking 45.2s, got %+v", flaky)
+	}
+
+	skipped := testSuite.TestCases[2]
+	if !skipped.IsSkipped() || skipped.Name != "[sig-compute]Hotplug [test_id:10811]should successfully plug vCPUs" {
+		t.Errorf("unexpected skipped test: %+v", skipped)
+	}
+}
+
+func TestReadSuiteLogFileRecoversPytestTests(t *testing.T) {
+	testSuite, err := readSuiteLogFile(path.Join("testdata", "logs", "tier2-log.txt"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Expected != 4 || testSuite.Tests != 3 || testSuite.Failures != 1 || testSuite.Skipped != 1 {
+		t.Errorf("unexpected counts: %+v", testSuite)
+	}
+
+	expected := []struct{ classname, name string }{
+		{"tests.virt.node.test_cpu.TestCPU", "test_cpu_sockets"},
+		{"tests.storage.test_hotplug", "test_hotplug_disk[block]"},
+		{"tests.network.test_bridge", "test_bridge_connectivity"},
+	}
+	for i, e := range expected {
+		if tc := testSuite.TestCases[i]; tc.Classname != e.classname || tc.Name != e.name {
+			t.Errorf("expected %s / %s, got %s / %s", e.classname, e.name, tc.Classname, tc.Name)
+		}
+	}
+}
+
+func TestReadSuiteLogCountsSuccinctBullets(t *testing.T) {
+	testSuite, err := readSuiteLog(strings.NewReader("Will run 10 of 10 specs\n•••\n•\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testSuite.Tests != 4 || testSuite.TestCases[3].Name != "unnamed test #4" {
+		t.Errorf("expected 4 unnamed tests, got %+v", testSuite.TestCases)
+	}
+}
+
+func TestReadSuiteLogWithoutCompletedTests(t *testing.T) {
+	_, err := readSuiteLog(strings.NewReader("Will run 10 of 10 specs\n[BeforeSuite] [FAILED] [1.2 seconds]\n"))
+	if err == nil {
+		t.Error("expected an error for a log without completed tests")
+	}
+}
+
+func TestNewResultMapFallsBackToSuiteLog(t *testing.T) {
+	dir := generateResDir(t, map[string]string{
+		"compute": `<testsuites><testsuite name="Tests Suite" tests="5"><testcase name="a"`,
+		"tier2":   "",
+	})
+
+	for sig, logName := range map[string]string{"compute": "compute-log.txt", "tier2": "tier2-log.txt"} {
+		content, err := os.ReadFile(path.Join("testdata", "logs", logName))
+		if err != nil {
+			t.Fatalf("failed to read fixture: %v", err)
+		}
+		if err := os.WriteFile(path.Join(dir, sig, logName), content, 0644); err != nil {
+			t.Fatalf("failed to write log: %v", err)
+		}
+	}
+	// A killed run leaves a non-zero exit code, which must not discard the recovered results.
+	if err := os.WriteFile(path.Join(dir, "tier2", ".exit_code"), []byte("143\n"), 0644); err != nil {
+		t.Fatalf("failed to write exit code: %v", err)
+	}
+
+	result, err := NewResultMap(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, sig := range []string{"compute", "tier2"} {
+		testSuite, ok := result[sig]
+		if !ok {
+			t.Fatalf("expected result for %s", sig)
+		}
+		if !testSuite.Incomplete || testSuite.SetupFailure || testSuite.Tests != 3 {
+			t.Errorf("expected %s to be recovered from its log, got %+v", sig, testSuite)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package junit
 
 import (
 	"encoding/xml"
+	"regexp"
+	"strings"
 )
 
 type TestSuites struct {
@@ -23,25 +25,260 @@ type TestSuite struct {
 	// SetupFailure is set when the test binary exited non-zero but JUnit
 	// reports zero failures, indicating a BeforeSuite or infrastructure error.
 	SetupFailure bool `xml:"-"`
+
+	// SubSuites holds the original <testsuite> elements when a JUnit file
+	// contains more than one of them and they were merged into this suite.
+	SubSuites []TestSuite `xml:"-"`
+
+	// Status is the outcome of the suite run as a whole, derived from the
+	// exit code and log of the run as well as from its results.
+	Status Status `xml:"-"`
+
+	// Incomplete is set when the suite was reconstructed from its log,
+	// because its result files were missing or unreadable. Expected then
+	// holds the number of tests the run was going to execute, if known.
+	Incomplete bool `xml:"-"`
+	Expected   int  `xml:"-"`
+
+	// Properties holds the suite's <properties>, e.g. the Ginkgo suite
+	// config or the pytest environment data.
+	Properties []Property `xml:"-"`
+
+	// Phases holds the timeline of the suite run, e.g. its setup, tests and
+	// cleanup, as recorded by the runner script in the suite directory.
+	Phases []Phase `xml:"-"`
+}
+
+// Property is a <property> of a <testsuite> or <testcase> element.
+type Property struct {
+	Name  string
+	Value string
+}
+
+// Property returns the value of the first suite property with the given
+// name, and whether it was found.
+func (ts TestSuite) Property(name string) (string, bool) {
+	for _, property := range ts.Properties {
+		if property.Name == name {
+			return property.Value, true
+		}
+	}
+	return "", false
+}
+
+// collapseRetries folds repeated JUnit entries of the same test, as written
+// by some reporters when a failed test is retried, into a single test case
+// holding the outcome of the last attempt. The header counts are adjusted so
+// that a retried test is counted once; an attempt with both a <failure> and
+// an <error> element was counted in both the failures and the errors.
+// Repeated entries where no attempt but the last one failed are kept as they
+// are, as those are distinct tests that happen to share a name.
+func collapseRetries(testSuite *TestSuite) {
+	type testKey struct{ classname, name string }
+
+	indexes := make(map[testKey][]int)
+	for i, testCase := range testSuite.TestCases {
+		key := testKey{testCase.Classname, testCase.Name}
+		indexes[key] = append(indexes[key], i)
+	}
+
+	dropped := make(map[int]bool)
+	for _, idx := range indexes {
+		if len(idx) < 2 {
+			continue
+		}
+
+		retried := false
+		for _, i := range idx[:len(idx)-1] {
+			if testSuite.TestCases[i].IsFailed() {
+				retried = true
+				break
+			}
+		}
+		if !retried {
+			continue
+		}
+
+		for _, i := range idx[:len(idx)-1] {
+			dropped[i] = true
+			testCase := testSuite.TestCases[i]
+			testSuite.Tests--
+			if testCase.Failure != nil {
+				testSuite.Failures--
+			}
+			if testCase.Error != nil {
+				testSuite.Errors--
+			}
+		}
+		testSuite.TestCases[idx[len(idx)-1]].Attempts = len(idx)
+	}
+
+	if len(dropped) == 0 {
+		return
+	}
+
+	testSuite.Tests = max(testSuite.Tests, 0)
+	testSuite.Failures = max(testSuite.Failures, 0)
+	testSuite.Errors = max(testSuite.Errors, 0)
+
+	testCases := make([]TestCase, 0, len(testSuite.TestCases)-len(dropped))
+	for i, testCase := range testSuite.TestCases {
+		if !dropped[i] {
+			testCases = append(testCases, testCase)
+		}
+	}
+	testSuite.TestCases = testCases
+}
+
+// mergeTestSuites aggregates several <testsuite> elements into a single
+// TestSuite, summing the header counts and concatenating the test cases.
+// The suites may have run in parallel, e.g. on several Ginkgo processes, so
+// the duration of the merged suite is the longest of theirs rather than
+// their sum. The original suites are kept in SubSuites for a per-suite
+// breakdown. When suites define the same property, the first suite's value
+// is kept.
+func mergeTestSuites(suites []TestSuite) TestSuite {
+	switch len(suites) {
+	case 0:
+		return TestSuite{}
+	case 1:
+		return suites[0]
+	}
+
+	merged := TestSuite{Name: suites[0].Name}
+	for _, suite := range suites {
+		merged.Tests += suite.Tests
+		merged.Failures += suite.Failures
+		merged.Errors += suite.Errors
+		merged.Skipped += suite.Skipped
+		merged.Disabled += suite.Disabled
+		merged.Time = max(merged.Time, suite.Time)
+		merged.TestCases = append(merged.TestCases, suite.TestCases...)
+		for _, property := range suite.Properties {
+			if _, ok := merged.Property(property.Name); !ok {
+				merged.Properties = append(merged.Properties, property)
+			}
+		}
+
+		merged.SubSuites = append(merged.SubSuites, suite)
+	}
+
+	return merged
 }
 
 type TestCase struct {
-	Name      string    `xml:"name,attr"`
-	Classname string    `xml:"classname,attr"`
-	Failure   TagExists `xml:"failure,omitempty"`
-	Error     TagExists `xml:"error,omitempty"`
-	SystemOut string    `xml:"system-out,omitempty"`
-	Skipped   TagExists `xml:"skipped,omitempty"`
+	Name      string   `xml:"name,attr"`
+	Classname string   `xml:"classname,attr"`
+	Time      float64  `xml:"time,attr"`
+	Failure   *Outcome `xml:"failure,omitempty"`
+	Error     *Outcome `xml:"error,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+	Skipped   *Outcome `xml:"skipped,omitempty"`
+
+	// Attempts is the number of times the test ran, including flake retries.
+	// It is taken from the Ginkgo JSON report, or from the number of repeated
+	// JUnit entries for the test. Zero means the number is unknown.
+	Attempts int `xml:"-"`
+
+	// Properties holds the test case's <properties>.
+	Properties []Property `xml:"-"`
+
+	// Metadata holds the tags parsed from the test name, and from the
+	// Ginkgo labels when known.
+	Metadata Metadata `xml:"-"`
+
+	// The fields below are not part of JUnit and are only populated when
+	// the suite was read from a Ginkgo JSON report.
+
+	// Labels holds the Ginkgo labels of the spec and its containers.
+	Labels []string `xml:"-"`
+	// Location is the code location of the spec, in "file:line" format.
+	Location string `xml:"-"`
+	// Container is the text of the spec's top-level container, e.g. the
+	// "[sig-compute]VM Live Migration" Describe.
+	Container string `xml:"-"`
+}
+
+// Outcome holds the content of a <failure>, <error> or <skipped> element.
+// A nil *Outcome on a TestCase means the element was not present.
+type Outcome struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+
+	// Location is the code location of the failure, in "file:line" format.
+	// Only populated from Ginkgo JSON reports.
+	Location string `xml:"-"`
+}
+
+// IsFailed reports whether the test case has a <failure> or an <error> element.
+func (tc TestCase) IsFailed() bool {
+	return tc.Failure != nil || tc.Error != nil
+}
+
+// IsSkipped reports whether the test case has a <skipped> element.
+func (tc TestCase) IsSkipped() bool {
+	return tc.Skipped != nil
 }
 
-type TagExists bool
+// IsFlaky reports whether the test case passed, but only after it failed at
+// least once and was retried.
+func (tc TestCase) IsFlaky() bool {
+	return tc.Attempts > 1 && !tc.IsFailed() && !tc.IsSkipped()
+}
 
-func (t *TagExists) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
-	err := d.Skip()
-	if err != nil {
-		return err
+// FailureReason returns the raw reason of a failed test case, preferring the
+// <failure> element over <error>, and the message attribute over the element
+// body. Returns an empty string if the test case did not fail.
+func (tc TestCase) FailureReason() string {
+	for _, outcome := range []*Outcome{tc.Failure, tc.Error} {
+		if outcome == nil {
+			continue
+		}
+		if msg := strings.TrimSpace(outcome.Message); msg != "" {
+			return msg
+		}
+		if text := strings.TrimSpace(outcome.Text); text != "" {
+			return text
+		}
+		return strings.TrimSpace(outcome.Type)
 	}
+	return ""
+}
+
+// SkipReasonNotSelected is the reason of the tests that were skipped without
+// a message, i.e. that were left out by the focus, skip and label filters of
+// the run rather than skipped at runtime.
+const SkipReasonNotSelected = "not selected by the run filters"
+
+// skipPrefixPattern matches the prefixes the test frameworks put in front
+// of a skip message, e.g. Ginkgo's "skipped - " and pytest's "Skipped: ".
+var skipPrefixPattern = regexp.MustCompile(`^(?i)(?:skip(?:ped)?\s*[-:]\s*)+`)
 
-	*t = true
-	return nil
+// SkipReason returns the reason a test case was skipped, from the message
+// attribute or the body of its <skipped> element. Tests filtered out of the
+// run carry no message and get SkipReasonNotSelected, unless they are
+// pending Ginkgo specs or expected pytest failures. Returns an empty string
+// if the test case was not skipped.
+func (tc TestCase) SkipReason() string {
+	if tc.Skipped == nil {
+		return ""
+	}
+
+	reason := strings.TrimSpace(tc.Skipped.Message)
+	if reason == "" {
+		reason = strings.TrimSpace(tc.Skipped.Text)
+	}
+	reason = skipPrefixPattern.ReplaceAllString(reason, "")
+
+	if reason != "" && !strings.EqualFold(reason, "skipped") {
+		return reason
+	}
+	switch tc.Skipped.Type {
+	case "pending":
+		return "pending"
+	case "xfail", "pytest.xfail":
+		return "expected failure"
+	}
+	return SkipReasonNotSelected
 }
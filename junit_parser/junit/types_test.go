@@ -2,6 +2,7 @@ package junit
 
 import (
 	"encoding/xml"
+	"strings"
 	"testing"
 )
 
@@ -40,10 +41,10 @@ func TestUnmarshalsValidTestSuites(t *testing.T) {
 		t.Errorf("expected 2 test cases, got %d", len(suite.TestCases))
 	}
 
-	if suite.TestCases[0].Failure {
+	if suite.TestCases[0].Failure != nil {
 		t.Errorf("expected first test case to not have failure, got true")
 	}
-	if !suite.TestCases[1].Failure {
+	if suite.TestCases[1].Failure == nil {
 		t.Errorf("expected second test case to have failure, got false")
 	}
 }
@@ -77,8 +78,11 @@ func TestUnmarshalsTestCaseWithFailure(t *testing.T) {
 	if testCase.Name != "test1" {
 		t.Errorf("expected test name to be 'test1', got '%s'", testCase.Name)
 	}
-	if !testCase.Failure {
-		t.Errorf("expected failure to be true, got false")
+	if testCase.Failure == nil {
+		t.Fatalf("expected failure to be true, got false")
+	}
+	if testCase.Failure.Text != "the test failed" {
+		t.Errorf("expected failure text to be 'the test failed', got '%s'", testCase.Failure.Text)
 	}
 }
 
@@ -97,7 +101,7 @@ func TestUnmarshalsTestCaseWithSkip(t *testing.T) {
 	if testCase.Name != "test1" {
 		t.Errorf("expected test name to be 'test1', got '%s'", testCase.Name)
 	}
-	if !testCase.Skipped {
+	if testCase.Skipped == nil {
 		t.Errorf("expected skipped to be true, got false")
 	}
 }
@@ -117,7 +121,7 @@ func TestUnmarshalsTestCaseWithError(t *testing.T) {
 	if testCase.Name != "test1" {
 		t.Errorf("expected test name to be 'test1', got '%s'", testCase.Name)
 	}
-	if !testCase.Error {
+	if testCase.Error == nil {
 		t.Errorf("expected error to be true, got false")
 	}
 }
@@ -134,7 +138,118 @@ func TestUnmarshalsTestCaseWithoutFailure(t *testing.T) {
 	if testCase.Name != "test1" {
 		t.Errorf("expected test name to be 'test1', got '%s'", testCase.Name)
 	}
-	if testCase.Failure {
+	if testCase.Failure != nil {
 		t.Errorf("expected failure to be false, got true")
 	}
+	if testCase.FailureReason() != "" {
+		t.Errorf("expected empty failure reason, got '%s'", testCase.FailureReason())
+	}
+}
+
+func TestUnmarshalsFailureMessageAndType(t *testing.T) {
+	data := `
+ <testcase name="test1" classname="tests.storage.test_hotplug">
+   <failure message="AssertionError: PVC not bound" type="AssertionError">Traceback (most recent call last):
+  File "test_hotplug.py", line 42</failure>
+ </testcase>`
+
+	var testCase TestCase
+	err := xml.Unmarshal([]byte(data), &testCase)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testCase.Failure == nil {
+		t.Fatalf("expected failure to be set")
+	}
+	if testCase.Failure.Message != "AssertionError: PVC not bound" {
+		t.Errorf("unexpected failure message: '%s'", testCase.Failure.Message)
+	}
+	if testCase.Failure.Type != "AssertionError" {
+		t.Errorf("unexpected failure type: '%s'", testCase.Failure.Type)
+	}
+	if !strings.HasPrefix(testCase.Failure.Text, "Traceback") {
+		t.Errorf("expected failure text to contain the traceback, got '%s'", testCase.Failure.Text)
+	}
+	if testCase.FailureReason() != "AssertionError: PVC not bound" {
+		t.Errorf("expected failure reason to prefer the message attribute, got '%s'", testCase.FailureReason())
+	}
+}
+
+func TestFailureReasonFallsBackToText(t *testing.T) {
+	data := `
+ <testcase name="test1" classname="Tests Suite">
+   <failure type="Failure">tests/migration/migration.go:1168&#xA;Timed out after 180.001s.</failure>
+ </testcase>`
+
+	var testCase TestCase
+	err := xml.Unmarshal([]byte(data), &testCase)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "tests/migration/migration.go:1168\nTimed out after 180.001s."
+	if testCase.FailureReason() != expected {
+		t.Errorf("expected failure reason '%s', got '%s'", expected, testCase.FailureReason())
+	}
+}
+
+func TestUnmarshalsSkippedMessage(t *testing.T) {
+	data := `
+ <testcase name="test1" classname="class1">
+   <skipped message="requires RWX block storage"/>
+ </testcase>`
+
+	var testCase TestCase
+	err := xml.Unmarshal([]byte(data), &testCase)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !testCase.IsSkipped() {
+		t.Fatalf("expected test case to be skipped")
+	}
+	if testCase.Skipped.Message != "requires RWX block storage" {
+		t.Errorf("unexpected skipped message: '%s'", testCase.Skipped.Message)
+	}
+}
+
+func TestUnmarshalsTestCaseTime(t *testing.T) {
+	data := `<testcase name="test1" classname="class1" time="117.776065299"></testcase>`
+
+	var testCase TestCase
+	err := xml.Unmarshal([]byte(data), &testCase)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testCase.Time != 117.776065299 {
+		t.Errorf("expected time to be 117.776065299, got %v", testCase.Time)
+	}
+}
+
+func TestSkipReason(t *testing.T) {
+	tests := map[string]struct {
+		skipped  *Outcome
+		expected string
+	}{
+		"not skipped":           {nil, ""},
+		"ginkgo runtime skip":   {&Outcome{Message: "skipped - requires in-place hotplug NICs"}, "requires in-place hotplug NICs"},
+		"ginkgo filtered out":   {&Outcome{Message: "skipped"}, SkipReasonNotSelected},
+		"ginkgo v1 filtered":    {&Outcome{}, SkipReasonNotSelected},
+		"ginkgo pending":        {&Outcome{Message: "pending", Type: "pending"}, "pending"},
+		"ginkgo report pending": {&Outcome{Type: "pending"}, "pending"},
+		"pytest skip message":   {&Outcome{Type: "pytest.skip", Message: "storage class does not support RWX"}, "storage class does not support RWX"},
+		"pytest skip text":      {&Outcome{Type: "pytest.skip", Text: "Skipped: unsupported on SNO"}, "unsupported on SNO"},
+		"pytest xfail":          {&Outcome{Type: "pytest.xfail"}, "expected failure"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testCase := TestCase{Name: "test1", Skipped: tt.skipped}
+			if reason := testCase.SkipReason(); reason != tt.expected {
+				t.Errorf("expected skip reason '%s', got '%s'", tt.expected, reason)
+			}
+		})
+	}
 }
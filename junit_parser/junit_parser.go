@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
 	"time"
 
+	"junitparser/baseline"
 	"junitparser/config"
 	"junitparser/configmap"
 	"junitparser/junit_parser/junit"
@@ -21,15 +25,138 @@ func main() {
 		os.Exit(1)
 	}
 
-	junitRes, err := junit.NewResultMap(cfg.ResultsDir)
+	registry := junit.DefaultRegistry()
+	if cfg.ResultSources != "" {
+		if err := registry.LoadFile(cfg.ResultSources); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	var policy *result.Policy
+	if cfg.Policy != "" {
+		loaded, err := result.LoadPolicy(cfg.Policy)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		policy = &loaded
+	}
+
+	junitRes, unknown, err := registry.ReadResults(cfg.ResultsDir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "unexpected error occurred; %v\n", err)
 		os.Exit(1)
 	}
 
+	for _, name := range unknown {
+		fmt.Fprintf(os.Stderr, "WARNING: no result source registered for directory %q; skipping\n", name)
+	}
+
 	testRes := result.New(junitRes)
+	testRes.MarkNotStarted(splitList(cfg.ExpectedSuites))
 
-	fmt.Print(testRes)
+	var issues *result.KnownIssues
+	if cfg.KnownIssues != "" {
+		loaded, err := result.LoadKnownIssues(splitList(cfg.KnownIssues)...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to load the known issues, skipping the known failures check; %v\n", err)
+		} else {
+			issues = &loaded
+		}
+	}
+
+	var skipLists result.KnownIssues
+	if cfg.SkipLists != "" {
+		skipLists, err = result.LoadKnownIssues(splitList(cfg.SkipLists)...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to load the skip lists, not telling the skip-listed tests apart; %v\n", err)
+		}
+	}
+
+	weights := result.DefaultScoreWeights()
+	if cfg.ScoreWeights != "" {
+		loaded, err := result.LoadScoreWeights(cfg.ScoreWeights)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to load the score weights, using the default ones; %v\n", err)
+		} else {
+			weights = loaded
+		}
+	}
+
+	runPhases, err := junit.ReadPhases(filepath.Join(cfg.ResultsDir, junit.PhasesFileName))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: failed to read the phases of the run; %v\n", err)
+	}
+
+	// annotate adds what is derived from the catalogs, the weights and the
+	// phases to a result built from the given suites.
+	annotate := func(res *result.Result, suites map[string]junit.TestSuite) {
+		if issues != nil {
+			res.FlagKnownFailures(*issues)
+		}
+		if skipLists != nil {
+			res.FlagSkipListedTests(skipLists, splitList(cfg.SkipListSuites), suites)
+		}
+		res.ScoreReadiness(weights, suites)
+		res.AddTimeline(runPhases, suites)
+	}
+	annotate(&testRes, junitRes)
+
+	if cfg.Baseline != "" {
+		baselineCtx, baselineCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		base, err := baseline.Load(baselineCtx, cfg.Baseline, registry)
+		baselineCancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to load the baseline, skipping the comparison; %v\n", err)
+		} else {
+			if base.Truncated {
+				fmt.Fprintf(os.Stderr, "WARNING: the failed tests of baseline %s were truncated; compare with its full results file (%s in its results directory) for a complete comparison\n", base.Source, fullResultsFileName)
+			}
+			testRes.Comparison = result.Compare(base, testRes, junitRes)
+		}
+	}
+
+	if policy != nil {
+		testRes.Evaluate(*policy, junitRes)
+	}
+
+	var summaryTemplate *template.Template
+	if cfg.Template != "" {
+		summaryTemplate, err = result.LoadTemplate(cfg.Template)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to load the summary template, using the built-in one; %v\n", err)
+		}
+	}
+
+	summaryRes := testRes
+	filter := junit.Filter{Crit: splitList(cfg.Crit), Level: splitList(cfg.Level)}
+	if !filter.IsEmpty() {
+		summaryRes = filteredSummary(cfg, filter, junitRes, testRes, annotate)
+	}
+
+	if err := writeOutput(cfg, testRes, summaryRes, summaryTemplate); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if cfg.HTMLReport != "" {
+		if err := writeHTMLReport(cfg, testRes); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to write the HTML report; %v\n", err)
+		}
+	}
+
+	if cfg.JUnitExport != "" {
+		if err := exportJUnit(cfg, cfg.JUnitExport, junitRes, false); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to write the merged JUnit XML; %v\n", err)
+		}
+	}
+
+	if cfg.PolarionExport != "" {
+		if err := exportJUnit(cfg, cfg.PolarionExport, junitRes, true); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to write the Polarion XML; %v\n", err)
+		}
+	}
 
 	if testRes.SetupFailure && len(testRes.SigMap) == 0 {
 		fmt.Fprintln(os.Stderr, "Skipping ConfigMap creation: no tests were executed due to setup failure")
@@ -44,6 +171,28 @@ func main() {
 
 	cm, err := configmap.New(cfg, resYaml)
 
+	if testRes.Comparison != nil {
+		comparisonYaml, err := testRes.Comparison.GetYaml()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to get comparison yaml: %v\n", err)
+			os.Exit(1)
+		}
+		cm.Data[configmap.ComparisonKey] = string(comparisonYaml)
+	}
+
+	truncated, fitErr := configmap.FitResults(cm, testRes, fullResultsFileName)
+	if truncated {
+		fmt.Fprintf(os.Stderr, "WARNING: the results are too large for a configmap, their lists of tests were truncated; the full results are in %s\n", fullResultsFileName)
+		if err := os.WriteFile(filepath.Join(cfg.ResultsDir, fullResultsFileName), resYaml, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to write the full results; %v\n", err)
+			delete(cm.Data, configmap.FullResultsFileKey)
+		}
+	}
+	if fitErr != nil {
+		fmt.Fprintf(os.Stderr, "failed to fit the results in the configmap: %v\n", fitErr)
+		os.Exit(1)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -52,4 +201,186 @@ func main() {
 		fmt.Fprintf(os.Stderr, "failed to create configmap: %v\n", err)
 		os.Exit(1)
 	}
+
+	if code := verdictExitCode(testRes.Summary.Verdict); code != 0 {
+		cancel()
+		os.Exit(code)
+	}
+}
+
+// fullResultsFileName is the file the full results are written to, in the
+// results directory, when they had to be truncated to fit in the configmap.
+const fullResultsFileName = "self-validation-results.yaml"
+
+// Exit codes of a run that was judged by a policy and did not pass.
+const (
+	exitCodeVerdictFailed       = 2
+	exitCodeVerdictInconclusive = 3
+)
+
+// verdictExitCode returns the exit code for the verdict of the policy; runs
+// without a policy exit with 0.
+func verdictExitCode(verdict result.Verdict) int {
+	switch verdict {
+	case result.VerdictFailed:
+		return exitCodeVerdictFailed
+	case result.VerdictInconclusive:
+		return exitCodeVerdictInconclusive
+	}
+	return 0
+}
+
+// filteredSummary returns the result restricted to the tests selected by the
+// crit and level filter, for the text summary only. The verdict and the
+// baseline comparison cover the whole run, so they are taken as they are.
+func filteredSummary(cfg config.Config, filter junit.Filter, junitRes map[string]junit.TestSuite, testRes result.Result,
+	annotate func(*result.Result, map[string]junit.TestSuite)) result.Result {
+	filteredRes := filter.Apply(junitRes)
+
+	summaryRes := result.New(filteredRes)
+	summaryRes.Summary.Filter = filter.String()
+	summaryRes.MarkNotStarted(splitList(cfg.ExpectedSuites))
+	annotate(&summaryRes, filteredRes)
+
+	summaryRes.Comparison = testRes.Comparison
+	summaryRes.Summary.Verdict = testRes.Summary.Verdict
+	summaryRes.Summary.VerdictReasons = testRes.Summary.VerdictReasons
+	return summaryRes
+}
+
+// writeOutput renders the result in the configured output format, to the
+// output file when one is set and to stdout otherwise. The text summary is
+// always printed to stdout, as the entrypoint keeps it in summary-log.txt,
+// and is rendered from summaryRes, with summaryTemplate when it is set.
+func writeOutput(cfg config.Config, testRes, summaryRes result.Result, summaryTemplate *template.Template) error {
+	format, err := result.ParseFormat(cfg.Output)
+	if err != nil {
+		return err
+	}
+
+	var rendered []byte
+	if format == result.FormatText {
+		rendered = summaryText(summaryRes, summaryTemplate)
+	} else if rendered, err = testRes.Render(format); err != nil {
+		return err
+	}
+
+	if cfg.OutputFile == "" {
+		_, err = os.Stdout.Write(rendered)
+		return err
+	}
+
+	if _, err := os.Stdout.Write(summaryText(summaryRes, summaryTemplate)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(cfg.OutputFile, rendered, 0644); err != nil {
+		return fmt.Errorf("failed to write output file %s: %w", cfg.OutputFile, err)
+	}
+	return nil
+}
+
+// summaryText renders the text summary with the given template, falling
+// back to the built-in layout when there is none or when it fails.
+func summaryText(testRes result.Result, summaryTemplate *template.Template) []byte {
+	if summaryTemplate == nil {
+		return []byte(testRes.String())
+	}
+
+	summary, err := testRes.RenderTemplate(summaryTemplate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: failed to render the summary template, using the built-in one; %v\n", err)
+		return []byte(testRes.String())
+	}
+	return summary
+}
+
+// writeHTMLReport writes the HTML report, linking each suite to its log and
+// k8s-reporter artifacts when they exist in the results directory.
+func writeHTMLReport(cfg config.Config, testRes result.Result) error {
+	reportFile := cfg.HTMLReport
+	if !filepath.IsAbs(reportFile) {
+		reportFile = filepath.Join(cfg.ResultsDir, reportFile)
+	}
+
+	sigs := make(map[string]bool)
+	for sig := range testRes.SigMap {
+		sigs[sig] = true
+	}
+	for sig := range testRes.Summary.SuiteStatuses {
+		sigs[sig] = true
+	}
+
+	links := make(map[string]result.SuiteLinks)
+	for sig := range sigs {
+		links[sig] = result.SuiteLinks{
+			Log:         artifactLink(reportFile, filepath.Join(cfg.ResultsDir, sig, sig+"-log.txt")),
+			K8sReporter: artifactLink(reportFile, filepath.Join(cfg.ResultsDir, sig, "k8s-reporter")),
+		}
+	}
+
+	html, err := testRes.HTML(links)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(reportFile, html, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", reportFile, err)
+	}
+	return nil
+}
+
+// exportJUnit writes the JUnit XML of all suites to fileName, relative to
+// the results directory, in the variant of the Polarion XUnit importer when
+// polarion is set.
+func exportJUnit(cfg config.Config, fileName string, junitRes map[string]junit.TestSuite, polarion bool) error {
+	if !filepath.IsAbs(fileName) {
+		fileName = filepath.Join(cfg.ResultsDir, fileName)
+	}
+
+	opts := junit.ExportOptions{Polarion: polarion, TestCaseIDPrefix: cfg.PolarionIDPrefix}
+	if !polarion {
+		opts.Properties = appendProperty(opts.Properties, "cnv-version", cfg.CNVVersion)
+		opts.Properties = appendProperty(opts.Properties, "start-timestamp", cfg.StartTimestamp)
+		opts.Properties = appendProperty(opts.Properties, "completion-timestamp", cfg.CompletionTimestamp)
+		return junit.ExportFile(fileName, junitRes, opts)
+	}
+
+	opts.Properties = appendProperty(opts.Properties, "polarion-project-id", cfg.PolarionProject)
+	opts.Properties = appendProperty(opts.Properties, "polarion-lookup-method", "id")
+	opts.Properties = appendProperty(opts.Properties, "polarion-testrun-title", "OpenShift Virtualization validation "+cfg.StartTimestamp)
+	opts.Properties = appendProperty(opts.Properties, "polarion-custom-cnvversion", cfg.CNVVersion)
+	opts.Properties = appendProperty(opts.Properties, "polarion-custom-starttimestamp", cfg.StartTimestamp)
+	opts.Properties = appendProperty(opts.Properties, "polarion-custom-completiontimestamp", cfg.CompletionTimestamp)
+	return junit.ExportFile(fileName, junitRes, opts)
+}
+
+// appendProperty appends a run property, unless its value is empty.
+func appendProperty(properties []junit.Property, name, value string) []junit.Property {
+	if value == "" {
+		return properties
+	}
+	return append(properties, junit.Property{Name: name, Value: value})
+}
+
+// artifactLink returns the path of an artifact relative to the report, or an
+// empty string when the artifact does not exist.
+func artifactLink(reportFile, artifact string) string {
+	if _, err := os.Stat(artifact); err != nil {
+		return ""
+	}
+	link, err := filepath.Rel(filepath.Dir(reportFile), artifact)
+	if err != nil {
+		return ""
+	}
+	return filepath.ToSlash(link)
+}
+
+// splitList splits a comma-separated flag value, ignoring empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
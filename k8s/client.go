@@ -57,3 +57,22 @@ func CreateCM(ctx context.Context, cm *corev1.ConfigMap) error {
 
 	return nil
 }
+
+func GetCM(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	config, err := getConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %v", err)
+	}
+
+	cli, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %v", err)
+	}
+
+	cm, err := cli.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap %s/%s: %v", namespace, name, err)
+	}
+
+	return cm, nil
+}
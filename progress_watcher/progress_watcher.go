@@ -23,6 +23,8 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+
+	"junitparser/junit_parser/junit"
 )
 
 var (
@@ -203,6 +205,7 @@ func main() {
 		}
 
 		logger.Println("Running dry-run discovery to determine total test counts...")
+		recordPhase(junit.PhaseStart, dryRunDiscoveryPhase)
 
 		// Run with timeout to avoid hanging
 		done := make(chan bool, 1)
@@ -213,6 +216,7 @@ func main() {
 
 		select {
 		case <-done:
+			recordPhase(junit.PhaseEnd, dryRunDiscoveryPhase)
 			totalTests := 0
 			for _, count := range preDiscoveredTotals {
 				totalTests += count
@@ -390,6 +394,19 @@ func getTotalExpectedSuites() int {
 	return count
 }
 
+// dryRunDiscoveryPhase is the phase of the run the dry-run discovery is
+// recorded as, for junit_parser to report it in the timeline of the run.
+const dryRunDiscoveryPhase = "dry-run-discovery"
+
+// recordPhase records the start or the end of a phase of the run in the
+// results directory, like the runner scripts do.
+func recordPhase(marker, phase string) {
+	fileName := filepath.Join(*resultsDir, junit.PhasesFileName)
+	if err := junit.AppendPhaseMarker(fileName, marker, phase, time.Now()); err != nil {
+		logger.Printf("Warning: Failed to record the %s of phase %s: %v\n", marker, phase, err)
+	}
+}
+
 // discoverTestTotalsByDryRun runs dry-run commands to discover total test counts upfront
 func discoverTestTotalsByDryRun(resultsDir string) map[string]int {
 	totals := make(map[string]int)
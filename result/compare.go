@@ -0,0 +1,307 @@
+package result
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"junitparser/junit_parser/junit"
+)
+
+// Baseline is a previous run that a run is compared with.
+type Baseline struct {
+	// Source describes where the baseline was read from, e.g. the name of
+	// its ConfigMap or the path of its results archive.
+	Source string
+	Result Result
+	// JUnitResults holds the raw results of the baseline run, when they are
+	// known, e.g. when the baseline was read from a results archive. Without
+	// them only the failed tests of the baseline are known.
+	JUnitResults map[string]junit.TestSuite
+	// Truncated is set when the lists of failed tests of the baseline were
+	// truncated to fit in its ConfigMap, and its full results are not known.
+	Truncated bool
+}
+
+// Comparison holds the differences between a run and a baseline run.
+type Comparison struct {
+	Baseline string `json:"baseline"`
+	// BaselineFailuresOnly is set when only the failed tests of the baseline
+	// are known, in which case only the tests that failed in the baseline
+	// can be reported as disappeared.
+	BaselineFailuresOnly bool `json:"baseline_failures_only,omitempty"`
+	// BaselineTruncated is set when the failed tests of the baseline were
+	// truncated, in which case the failed tests left out of its lists are
+	// reported as newly failing.
+	BaselineTruncated bool                       `json:"baseline_truncated,omitempty"`
+	Suites            map[string]SuiteComparison `json:"suites,omitempty"`
+	// TotalDeltas holds the differences of the total counts, the current
+	// run minus the baseline.
+	TotalDeltas TestCounts `json:"total_deltas"`
+}
+
+// SuiteComparison holds the differences of a suite between a run and a
+// baseline run.
+type SuiteComparison struct {
+	// Deltas holds the differences of the counts of the suite, the current
+	// run minus the baseline.
+	Deltas TestCounts `json:"deltas"`
+	// NewlyFailing lists the tests that failed in the current run, but not
+	// in the baseline, including tests the baseline did not have.
+	NewlyFailing []string `json:"newly_failing,omitempty"`
+	// NewlyPassing lists the tests that failed in the baseline and passed in
+	// the current run.
+	NewlyPassing []string `json:"newly_passing,omitempty"`
+	// Disappeared lists the tests that ran in the baseline but were skipped
+	// or missing in the current run.
+	Disappeared []string `json:"disappeared,omitempty"`
+	// Omitted counts the tests left out of the lists of the suite, per
+	// list, when the comparison was truncated to fit in its ConfigMap.
+	Omitted map[string]int `json:"omitted,omitempty"`
+}
+
+type testOutcome int
+
+// testKey identifies a test of a suite. Test names are not unique across
+// pytest classes, so a pytest test is keyed by its classname as well, or by
+// its category when only the failed tests of the baseline are known, as
+// those are only listed per category. Ginkgo tests are keyed by name alone.
+type testKey struct {
+	group string
+	name  string
+}
+
+const (
+	outcomePassed testOutcome = iota
+	outcomeFailed
+	outcomeSkipped
+)
+
+// Compare compares a run with a baseline run. currentJUnit holds the raw
+// results the current run was built from; suites that are not part of the
+// current result, e.g. because they failed during setup, are ignored.
+func Compare(baseline Baseline, current Result, currentJUnit map[string]junit.TestSuite) *Comparison {
+	comparison := &Comparison{
+		Baseline:             baseline.Source,
+		BaselineFailuresOnly: baseline.JUnitResults == nil,
+		BaselineTruncated:    baseline.Truncated,
+		Suites:               make(map[string]SuiteComparison),
+	}
+
+	sigs := make(map[string]bool)
+	for sig := range current.SigMap {
+		sigs[sig] = true
+	}
+	for sig := range baseline.Result.SigMap {
+		sigs[sig] = true
+	}
+
+	for sig := range sigs {
+		// Only pytest tests are keyed by their classname or category. Those
+		// of Ginkgo tests depend on whether the run wrote a Ginkgo JSON
+		// report, and their names are unique within a suite anyway.
+		pytest := hasPytestTests(currentJUnit[sig]) || hasPytestTests(baseline.JUnitResults[sig])
+		var suiteGroupOf func(junit.TestCase) string
+		var baselineOutcomes map[testKey]testOutcome
+		if baseline.JUnitResults != nil {
+			if pytest {
+				suiteGroupOf = func(testCase junit.TestCase) string { return testCase.Classname }
+			}
+			baselineOutcomes = junitOutcomes(baseline.JUnitResults, baseline.Result, sig, suiteGroupOf)
+		} else {
+			baselineFailures := baseline.Result.SigMap[sig].FailedTests
+			// Baselines written before the failed tests were categorized
+			// list them all without a category.
+			if pytest && !baselineFailures.isFlat() {
+				suiteGroupOf = testCategory
+			}
+			baselineOutcomes = failedOutcomes(baselineFailures, suiteGroupOf != nil)
+		}
+		currentOutcomes := junitOutcomes(currentJUnit, current, sig, suiteGroupOf)
+		displayName := testDisplayNames(currentOutcomes, baselineOutcomes)
+
+		suite := SuiteComparison{
+			Deltas: countDeltas(sigCounts(current.SigMap[sig]), sigCounts(baseline.Result.SigMap[sig])),
+		}
+		for key, outcome := range currentOutcomes {
+			if outcome == outcomeFailed && baselineOutcomes[key] != outcomeFailed {
+				suite.NewlyFailing = append(suite.NewlyFailing, displayName(key))
+			}
+		}
+		for key, outcome := range baselineOutcomes {
+			currentOutcome, ok := currentOutcomes[key]
+			if outcome == outcomeFailed && ok && currentOutcome == outcomePassed {
+				suite.NewlyPassing = append(suite.NewlyPassing, displayName(key))
+			}
+			if outcome != outcomeSkipped && (!ok || currentOutcome == outcomeSkipped) {
+				suite.Disappeared = append(suite.Disappeared, displayName(key))
+			}
+		}
+		sort.Strings(suite.NewlyFailing)
+		sort.Strings(suite.NewlyPassing)
+		sort.Strings(suite.Disappeared)
+
+		comparison.Suites[sig] = suite
+	}
+
+	comparison.TotalDeltas = countDeltas(
+		TestCounts{Run: current.Summary.Run, Passed: current.Summary.Passed, Failures: current.Summary.Failed, Skipped: current.Summary.Skipped},
+		TestCounts{Run: baseline.Result.Summary.Run, Passed: baseline.Result.Summary.Passed, Failures: baseline.Result.Summary.Failed, Skipped: baseline.Result.Summary.Skipped},
+	)
+
+	return comparison
+}
+
+// junitOutcomes returns the outcome of every test of a suite, keyed by the
+// group groupOf returns, if any, and the test name, or nil when the suite is
+// not part of the result.
+func junitOutcomes(junitResults map[string]junit.TestSuite, res Result, sig string, groupOf func(junit.TestCase) string) map[testKey]testOutcome {
+	if _, ok := res.SigMap[sig]; !ok {
+		return nil
+	}
+
+	outcomes := make(map[testKey]testOutcome)
+	for _, testCase := range junitResults[sig].TestCases {
+		key := testKey{name: testCase.Name}
+		if groupOf != nil {
+			key.group = groupOf(testCase)
+		}
+		switch {
+		case testCase.IsFailed():
+			outcomes[key] = outcomeFailed
+		case testCase.IsSkipped():
+			outcomes[key] = outcomeSkipped
+		default:
+			outcomes[key] = outcomePassed
+		}
+	}
+	return outcomes
+}
+
+// failedOutcomes returns the failed tests of a suite as outcomes, keyed by
+// their category when byCategory is set and by their name alone otherwise.
+func failedOutcomes(failedTests FailedTestsMap, byCategory bool) map[testKey]testOutcome {
+	outcomes := make(map[testKey]testOutcome)
+	for category, testNames := range failedTests {
+		for _, testName := range testNames {
+			key := testKey{name: testName}
+			if byCategory {
+				key.group = category
+			}
+			outcomes[key] = outcomeFailed
+		}
+	}
+	return outcomes
+}
+
+// hasPytestTests reports whether a suite has tests with a pytest classname.
+func hasPytestTests(testSuite junit.TestSuite) bool {
+	for _, testCase := range testSuite.TestCases {
+		if extractCategory(testCase.Classname) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// testDisplayNames returns how to list a test of the comparison: by its name,
+// followed by its classname or category when other tests of the suite have
+// the same name.
+func testDisplayNames(outcomes ...map[testKey]testOutcome) func(testKey) string {
+	groups := make(map[string]map[string]bool)
+	for _, m := range outcomes {
+		for key := range m {
+			if groups[key.name] == nil {
+				groups[key.name] = make(map[string]bool)
+			}
+			groups[key.name][key.group] = true
+		}
+	}
+
+	return func(key testKey) string {
+		if len(groups[key.name]) < 2 || key.group == "" {
+			return key.name
+		}
+		return fmt.Sprintf("%s (%s)", key.name, key.group)
+	}
+}
+
+func sigCounts(sigRes Sig) TestCounts {
+	return TestCounts{Run: sigRes.Run, Passed: sigRes.Passed, Failures: sigRes.Failures, Skipped: sigRes.Skipped}
+}
+
+func countDeltas(current, baseline TestCounts) TestCounts {
+	return TestCounts{
+		Run:      current.Run - baseline.Run,
+		Passed:   current.Passed - baseline.Passed,
+		Failures: current.Failures - baseline.Failures,
+		Skipped:  current.Skipped - baseline.Skipped,
+	}
+}
+
+// String returns the comparison in the layout of the text summary.
+func (c *Comparison) String() string {
+	sb := strings.Builder{}
+
+	header := fmt.Sprintf("Comparison with baseline %s", c.Baseline)
+	seperator := strings.Repeat("=", len(header))
+	sb.WriteString(seperator + "\n")
+	sb.WriteString(header + "\n")
+	sb.WriteString(seperator + "\n")
+	if c.BaselineFailuresOnly {
+		sb.WriteString("NOTE: Only the failed tests of the baseline are known.\n")
+	}
+	if c.BaselineTruncated {
+		sb.WriteString("NOTE: The failed tests of the baseline were truncated; the ones left out are reported as newly failing.\n")
+	}
+
+	for _, sig := range sortedKeys(c.Suites) {
+		suite := c.Suites[sig]
+		sb.WriteString(fmt.Sprintf("%s: %s\n", sig, formatDeltas(suite.Deltas)))
+		for _, list := range []struct {
+			title string
+			tests []string
+		}{
+			{"Newly Failing", suite.NewlyFailing},
+			{"Newly Passing", suite.NewlyPassing},
+			{"Disappeared", suite.Disappeared},
+		} {
+			if len(list.tests) == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("  %s:\n", list.title))
+			for _, testName := range list.tests {
+				sb.WriteString(fmt.Sprintf("    - %s\n", testName))
+			}
+		}
+	}
+	sb.WriteString(fmt.Sprintf("Total: %s\n", formatDeltas(c.TotalDeltas)))
+
+	return sb.String()
+}
+
+// formatDeltas formats count differences, e.g.
+// "run +2, passed +1, failed +1, skipped 0".
+func formatDeltas(deltas TestCounts) string {
+	return fmt.Sprintf("run %s, passed %s, failed %s, skipped %s",
+		signedCount(deltas.Run), signedCount(deltas.Passed), signedCount(deltas.Failures), signedCount(deltas.Skipped))
+}
+
+// signedCount formats a count difference with an explicit sign when positive.
+func signedCount(n int) string {
+	if n > 0 {
+		return fmt.Sprintf("+%d", n)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// GetYaml converts the comparison to YAML format.
+func (c *Comparison) GetYaml() ([]byte, error) {
+	cmpYaml, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode comparison for yaml: %w", err)
+	}
+	return cmpYaml, nil
+}
@@ -0,0 +1,261 @@
+package result_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func baselineJUnitResults() map[string]junit.TestSuite {
+	return map[string]junit.TestSuite{
+		"compute": {
+			Tests:    4,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "test1", Classname: "Tests Suite"},
+				{Name: "test2", Classname: "Tests Suite", Failure: &junit.Outcome{Message: "boom"}},
+				{Name: "test3", Classname: "Tests Suite"},
+				{Name: "test4", Classname: "Tests Suite"},
+			},
+		},
+		"network": {
+			Tests:     1,
+			Failures:  1,
+			TestCases: []junit.TestCase{{Name: "test1", Classname: "Tests Suite", Failure: &junit.Outcome{}}},
+		},
+	}
+}
+
+func currentJUnitResults() map[string]junit.TestSuite {
+	return map[string]junit.TestSuite{
+		"compute": {
+			Tests:    4,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "test1", Classname: "Tests Suite", Failure: &junit.Outcome{Message: "boom"}},
+				{Name: "test2", Classname: "Tests Suite"},
+				{Name: "test3", Classname: "Tests Suite", Skipped: &junit.Outcome{}},
+				{Name: "test5", Classname: "Tests Suite"},
+			},
+		},
+	}
+}
+
+func TestComparesWithBaselineResults(t *testing.T) {
+	baselineJUnit := baselineJUnitResults()
+	currentJUnit := currentJUnitResults()
+	current := result.New(currentJUnit)
+
+	comparison := result.Compare(result.Baseline{
+		Source:       "test-results-20261015.tar.gz",
+		Result:       result.New(baselineJUnit),
+		JUnitResults: baselineJUnit,
+	}, current, currentJUnit)
+
+	if comparison.BaselineFailuresOnly {
+		t.Error("expected the baseline tests to be fully known")
+	}
+
+	compute := comparison.Suites["compute"]
+	expected := result.SuiteComparison{
+		Deltas:       result.TestCounts{Run: 0, Passed: 0, Failures: 0, Skipped: 1},
+		NewlyFailing: []string{"test1"},
+		NewlyPassing: []string{"test2"},
+		Disappeared:  []string{"test3", "test4"},
+	}
+	if !reflect.DeepEqual(compute, expected) {
+		t.Errorf("expected compute comparison %+v, got %+v", expected, compute)
+	}
+
+	network := comparison.Suites["network"]
+	if network.Deltas.Run != -1 || network.Deltas.Failures != -1 || !reflect.DeepEqual(network.Disappeared, []string{"test1"}) {
+		t.Errorf("expected the network suite to have disappeared, got %+v", network)
+	}
+	if comparison.TotalDeltas.Run != -1 || comparison.TotalDeltas.Failures != -1 {
+		t.Errorf("unexpected total deltas: %+v", comparison.TotalDeltas)
+	}
+
+	current.Comparison = comparison
+	output := current.String()
+	for _, expected := range []string{
+		"Comparison with baseline test-results-20261015.tar.gz\n",
+		"compute: run 0, passed 0, failed 0, skipped +1\n  Newly Failing:\n    - test1\n  Newly Passing:\n    - test2\n  Disappeared:\n    - test3\n    - test4\n",
+		"network: run -1, passed 0, failed -1, skipped 0\n",
+		"Total: run -1, passed 0, failed -1, skipped +1\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestComparesWithBaselineConfigMap(t *testing.T) {
+	baselineYaml, err := result.New(baselineJUnitResults()).GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting result to YAML: %v", err)
+	}
+	baselineRes, err := result.Parse(baselineYaml)
+	if err != nil {
+		t.Fatalf("unexpected error parsing the baseline: %v", err)
+	}
+
+	currentJUnit := currentJUnitResults()
+	comparison := result.Compare(result.Baseline{Source: "configmap/ocp-virt-validation-20261015", Result: baselineRes},
+		result.New(currentJUnit), currentJUnit)
+
+	if !comparison.BaselineFailuresOnly {
+		t.Error("expected only the failed tests of the baseline to be known")
+	}
+
+	compute := comparison.Suites["compute"]
+	if !reflect.DeepEqual(compute.NewlyFailing, []string{"test1"}) || !reflect.DeepEqual(compute.NewlyPassing, []string{"test2"}) {
+		t.Errorf("unexpected compute comparison: %+v", compute)
+	}
+	if len(compute.Disappeared) != 0 {
+		t.Errorf("expected no disappeared tests when only the failures are known, got %v", compute.Disappeared)
+	}
+
+	comparisonYaml, err := comparison.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting the comparison to YAML: %v", err)
+	}
+	for _, expected := range []string{
+		"baseline: configmap/ocp-virt-validation-20261015\n",
+		"baseline_failures_only: true\n",
+		"    newly_failing:\n    - test1\n",
+	} {
+		if !strings.Contains(string(comparisonYaml), expected) {
+			t.Errorf("expected YAML to contain %q, got:\n%s", expected, comparisonYaml)
+		}
+	}
+}
+
+func TestParsesResultFromYAMLAndJSON(t *testing.T) {
+	res := result.New(baselineJUnitResults())
+
+	yamlData, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting result to YAML: %v", err)
+	}
+	jsonData, err := res.Render(result.FormatJSON)
+	if err != nil {
+		t.Fatalf("unexpected error converting result to JSON: %v", err)
+	}
+
+	for name, data := range map[string][]byte{"yaml": yamlData, "json": jsonData} {
+		parsed, err := result.Parse(data)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %v", name, err)
+		}
+		if !reflect.DeepEqual(parsed.Summary, res.Summary) {
+			t.Errorf("expected %s summary %+v, got %+v", name, res.Summary, parsed.Summary)
+		}
+		if len(parsed.SigMap) != 2 || parsed.SigMap["compute"].Failures != 1 ||
+			!reflect.DeepEqual(parsed.SigMap["compute"].FailedTests, res.SigMap["compute"].FailedTests) {
+			t.Errorf("unexpected %s suites: %+v", name, parsed.SigMap)
+		}
+	}
+
+	if _, err := result.Parse([]byte("compute: [")); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestComparesTestsWithTheSameNameInDifferentClasses(t *testing.T) {
+	baselineJUnit := map[string]junit.TestSuite{
+		"tier2": {
+			Tests:    2,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "test_start", Classname: "tests.virt.node.test_a.TestA", Failure: &junit.Outcome{}},
+				{Name: "test_start", Classname: "tests.virt.node.test_b.TestB"},
+			},
+		},
+	}
+	currentJUnit := map[string]junit.TestSuite{
+		"tier2": {
+			Tests:    2,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "test_start", Classname: "tests.virt.node.test_a.TestA"},
+				{Name: "test_start", Classname: "tests.virt.node.test_b.TestB", Failure: &junit.Outcome{}},
+			},
+		},
+	}
+
+	comparison := result.Compare(result.Baseline{
+		Source:       "test-results-20261015.tar.gz",
+		Result:       result.New(baselineJUnit),
+		JUnitResults: baselineJUnit,
+	}, result.New(currentJUnit), currentJUnit)
+
+	tier2 := comparison.Suites["tier2"]
+	if !reflect.DeepEqual(tier2.NewlyFailing, []string{"test_start (tests.virt.node.test_b.TestB)"}) ||
+		!reflect.DeepEqual(tier2.NewlyPassing, []string{"test_start (tests.virt.node.test_a.TestA)"}) {
+		t.Errorf("expected the tests to be told apart by their classname, got %+v", tier2)
+	}
+}
+
+func TestComparesWithTruncatedBaseline(t *testing.T) {
+	baselineRes := result.New(baselineJUnitResults()).Truncate(0)
+	if !baselineRes.IsTruncated() {
+		t.Fatal("expected the baseline to be truncated")
+	}
+
+	currentJUnit := currentJUnitResults()
+	current := result.New(currentJUnit)
+	current.Comparison = result.Compare(result.Baseline{Source: "configmap/ocp-virt-validation-20261015", Result: baselineRes, Truncated: true},
+		current, currentJUnit)
+
+	if !current.Comparison.BaselineTruncated {
+		t.Error("expected the comparison to record that the baseline was truncated")
+	}
+	if output := current.String(); !strings.Contains(output, "NOTE: The failed tests of the baseline were truncated") {
+		t.Errorf("expected the output to note the truncated baseline, got:\n%s", output)
+	}
+}
+
+func TestComparesJUnitBaselineWithGinkgoReportRun(t *testing.T) {
+	// The JUnit reporter of KubeVirt sets the classname of every test to
+	// "Tests Suite", while a Ginkgo JSON report gives its containers.
+	baselineJUnit := map[string]junit.TestSuite{
+		"compute": {
+			Tests:    2,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "[sig-compute]VM Live Migration should migrate", Classname: "Tests Suite", Metadata: junit.Metadata{Sigs: []string{"sig-compute"}}},
+				{Name: "[sig-compute]VM Live Migration should fail", Classname: "Tests Suite", Metadata: junit.Metadata{Sigs: []string{"sig-compute"}}, Failure: &junit.Outcome{}},
+			},
+		},
+	}
+	currentJUnit := map[string]junit.TestSuite{
+		"compute": {
+			Tests:    2,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "[sig-compute]VM Live Migration should migrate", Classname: "[sig-compute]VM Live Migration", Container: "[sig-compute]VM Live Migration",
+					Metadata: junit.Metadata{Sigs: []string{"sig-compute"}}, Failure: &junit.Outcome{}},
+				{Name: "[sig-compute]VM Live Migration should fail", Classname: "[sig-compute]VM Live Migration", Container: "[sig-compute]VM Live Migration",
+					Metadata: junit.Metadata{Sigs: []string{"sig-compute"}}, Failure: &junit.Outcome{}},
+			},
+		},
+	}
+	current := result.New(currentJUnit)
+	expected := result.SuiteComparison{
+		NewlyFailing: []string{"[sig-compute]VM Live Migration should migrate"},
+	}
+
+	for name, baseline := range map[string]result.Baseline{
+		"archive":   {Source: "test-results-20261015.tar.gz", Result: result.New(baselineJUnit), JUnitResults: baselineJUnit},
+		"configmap": {Source: "configmap/ocp-virt-validation-20261015", Result: result.New(baselineJUnit)},
+	} {
+		compute := result.Compare(baseline, current, currentJUnit).Suites["compute"]
+		compute.Deltas = result.TestCounts{}
+		if !reflect.DeepEqual(compute, expected) {
+			t.Errorf("expected the %s baseline comparison %+v, got %+v", name, expected, compute)
+		}
+	}
+}
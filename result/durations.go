@@ -0,0 +1,88 @@
+package result
+
+import (
+	"sort"
+
+	"junitparser/junit_parser/junit"
+)
+
+// slowestTestsLimit is the number of tests reported in the slowest tests lists.
+const slowestTestsLimit = 10
+
+// TestDuration represents the execution time of a single test.
+type TestDuration struct {
+	Suite    string `json:"suite,omitempty"`
+	Name     string `json:"name"`
+	Duration string `json:"duration"`
+
+	seconds float64
+}
+
+// slowestTests returns up to limit tests ordered from the slowest to the fastest.
+// Tests without a recorded time (e.g. skipped tests) are ignored.
+func slowestTests(tests []TestDuration, limit int) []TestDuration {
+	var timed []TestDuration
+	for _, test := range tests {
+		if test.seconds > 0 {
+			timed = append(timed, test)
+		}
+	}
+
+	sort.SliceStable(timed, func(i, j int) bool {
+		if timed[i].seconds != timed[j].seconds {
+			return timed[i].seconds > timed[j].seconds
+		}
+		if timed[i].Suite != timed[j].Suite {
+			return timed[i].Suite < timed[j].Suite
+		}
+		return timed[i].Name < timed[j].Name
+	})
+
+	if len(timed) > limit {
+		timed = timed[:limit]
+	}
+	return timed
+}
+
+// testDurations collects the execution time of every test case in the suite.
+func testDurations(sig string, testCases []junit.TestCase) []TestDuration {
+	durations := make([]TestDuration, 0, len(testCases))
+	for _, testCase := range testCases {
+		durations = append(durations, TestDuration{
+			Suite:    sig,
+			Name:     testCase.Name,
+			Duration: formatDuration(testCase.Time),
+			seconds:  testCase.Time,
+		})
+	}
+	return durations
+}
+
+// categoryDurations sums the execution time of the test cases per category.
+// Returns nil when none of the test cases can be categorized, since a single
+// uncategorized total would only repeat the suite duration.
+func categoryDurations(testCases []junit.TestCase) map[string]string {
+	totals := make(map[string]float64)
+	categorized := false
+	for _, testCase := range testCases {
+		category := testCategory(testCase)
+		if category != "" {
+			categorized = true
+		} else {
+			category = "uncategorized"
+		}
+		totals[category] += testCase.Time
+	}
+
+	if !categorized {
+		return nil
+	}
+
+	durations := make(map[string]string, len(totals))
+	for category, seconds := range totals {
+		if duration := formatDuration(seconds); duration != "" {
+			durations[category] = duration
+		}
+	}
+	return durations
+}
@@ -0,0 +1,114 @@
+package result_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func TestReportsSlowestTestsPerSuiteAndOverall(t *testing.T) {
+	var computeCases []junit.TestCase
+	for i := 1; i <= 12; i++ {
+		computeCases = append(computeCases, junit.TestCase{Name: fmt.Sprintf("compute%02d", i), Time: float64(i * 10)})
+	}
+
+	junitResults := map[string]junit.TestSuite{
+		"compute": {Tests: 12, TestCases: computeCases},
+		"network": {
+			Tests: 2,
+			TestCases: []junit.TestCase{
+				{Name: "network-slow", Time: 1000},
+				{Name: "network-skipped", Skipped: &junit.Outcome{}},
+			},
+		},
+	}
+
+	res := result.New(junitResults)
+
+	compute := res.SigMap["compute"].SlowestTests
+	if len(compute) != 10 {
+		t.Fatalf("expected 10 slowest tests for compute, got %d", len(compute))
+	}
+	if compute[0].Name != "compute12" || compute[0].Duration != "2m0s" {
+		t.Errorf("expected compute12 (2m0s) to be the slowest compute test, got %+v", compute[0])
+	}
+	if compute[0].Suite != "" {
+		t.Errorf("expected per-suite entries to omit the suite name, got %q", compute[0].Suite)
+	}
+	if compute[9].Name != "compute03" {
+		t.Errorf("expected compute03 to be the 10th slowest compute test, got %s", compute[9].Name)
+	}
+
+	network := res.SigMap["network"].SlowestTests
+	if len(network) != 1 {
+		t.Errorf("expected skipped tests without time to be ignored, got %+v", network)
+	}
+
+	overall := res.Summary.SlowestTests
+	if len(overall) != 10 {
+		t.Fatalf("expected 10 slowest tests overall, got %d", len(overall))
+	}
+	if overall[0].Suite != "network" || overall[0].Name != "network-slow" {
+		t.Errorf("expected network-slow to be the slowest test overall, got %+v", overall[0])
+	}
+	if overall[1].Suite != "compute" || overall[1].Name != "compute12" {
+		t.Errorf("expected compute12 to be the second slowest test overall, got %+v", overall[1])
+	}
+
+	output := res.String()
+	if !strings.Contains(output, "Slowest Tests:\n  - 16m40s network-slow\n") {
+		t.Errorf("expected per-suite slowest tests in output, got:\n%s", output)
+	}
+	if !strings.Contains(output, "  - 16m40s [network] network-slow\n") {
+		t.Errorf("expected overall slowest tests with suite prefix in output, got:\n%s", output)
+	}
+}
+
+func TestReportsTimePerCategory(t *testing.T) {
+	junitResults := map[string]junit.TestSuite{
+		"tier2": {
+			Tests: 4,
+			TestCases: []junit.TestCase{
+				{Name: "test_hotplug", Classname: "tests.storage.test_hotplug.TestHotPlug", Time: 30},
+				{Name: "test_resize", Classname: "tests.storage.test_resize", Time: 45},
+				{Name: "test_smbios", Classname: "tests.virt.cluster.general.test_smbios", Time: 90},
+				{Name: "test_other", Classname: "conftest", Time: 5},
+			},
+		},
+		"compute": {
+			Tests: 1,
+			TestCases: []junit.TestCase{
+				{Name: "test_migration", Classname: "Tests Suite", Time: 60},
+			},
+		},
+	}
+
+	res := result.New(junitResults)
+
+	expected := map[string]string{
+		"storage":       "1m15s",
+		"virt/cluster":  "1m30s",
+		"uncategorized": "5s",
+	}
+	got := res.SigMap["tier2"].CategoryDurations
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d categories, got %v", len(expected), got)
+	}
+	for cat, duration := range expected {
+		if got[cat] != duration {
+			t.Errorf("expected %s duration %s, got %s", cat, duration, got[cat])
+		}
+	}
+
+	if res.SigMap["compute"].CategoryDurations != nil {
+		t.Errorf("expected no category durations for an uncategorized suite, got %v", res.SigMap["compute"].CategoryDurations)
+	}
+
+	output := res.String()
+	if !strings.Contains(output, "Time per Category:\n  storage: 1m15s\n  uncategorized: 5s\n  virt/cluster: 1m30s\n") {
+		t.Errorf("expected time per category in output, got:\n%s", output)
+	}
+}
@@ -0,0 +1,172 @@
+package result
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"strings"
+
+	"junitparser/junit_parser/junit"
+)
+
+//go:embed report.html.tmpl
+var reportTemplateText string
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).Parse(reportTemplateText))
+
+// SuiteLinks holds the paths, relative to the report, of the artifacts of
+// a suite run. Empty paths are not linked.
+type SuiteLinks struct {
+	Log         string
+	K8sReporter string
+}
+
+// htmlReport is the data the HTML report template is executed with.
+type htmlReport struct {
+	Timestamp       string
+	Result          Result
+	NoTestsExecuted bool
+	VerdictClass    string
+	Suites          []htmlSuite
+}
+
+// htmlSuite is a suite of the HTML report. Suites that were left out of the
+// results, e.g. because they failed during setup, only have a status.
+type htmlSuite struct {
+	Name              string
+	Status            junit.Status
+	StatusClass       string
+	HasResults        bool
+	Sig               Sig
+	Links             SuiteLinks
+	IncompleteWarning string
+	Failed            []htmlFailedTest
+	SkipReasons       []htmlSkipReason
+}
+
+type htmlFailedTest struct {
+	Category string
+	Name     string
+	Reason   string
+	// Known is set when the test matches a known issue, which Issue links
+	// to when known.
+	Known bool
+	Issue string
+}
+
+type htmlSkipReason struct {
+	Reason string
+	Count  int
+}
+
+// HTML renders the result as a self-contained HTML page, with no external
+// assets, so that it can be opened straight from the results PVC or from the
+// results archive. links maps a suite to its artifacts.
+func (r Result) HTML(links map[string]SuiteLinks) ([]byte, error) {
+	report := htmlReport{
+		Timestamp:       os.Getenv("TIMESTAMP"),
+		Result:          r,
+		NoTestsExecuted: r.SetupFailure && len(r.SigMap) == 0,
+		VerdictClass:    verdictClass(r.Summary.Verdict),
+	}
+
+	sigs := sortedKeys(r.SigMap)
+	for _, sig := range sortedKeys(r.Summary.SuiteStatuses) {
+		if _, ok := r.SigMap[sig]; !ok {
+			sigs = append(sigs, sig)
+		}
+	}
+	sort.Strings(sigs)
+
+	for _, sig := range sigs {
+		sigRes, ok := r.SigMap[sig]
+		suite := htmlSuite{
+			Name:       sig,
+			Status:     r.Summary.SuiteStatuses[sig],
+			HasResults: ok,
+			Sig:        sigRes,
+			Links:      links[sig],
+		}
+		if ok {
+			if sigRes.Status != "" {
+				suite.Status = sigRes.Status
+			}
+			if sigRes.Incomplete {
+				suite.IncompleteWarning = strings.TrimPrefix(incompleteWarning(sigRes), "WARNING: ")
+			}
+			suite.Failed = htmlFailedTests(sigRes.FailedTests, sigRes.FailureReasons, sigRes.KnownFailures)
+			suite.SkipReasons = htmlSkipReasons(sigRes.SkippedByReason)
+		}
+		suite.StatusClass = statusClass(suite.Status, sigRes.Failures)
+		report.Suites = append(report.Suites, suite)
+	}
+
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, report); err != nil {
+		return nil, fmt.Errorf("failed to render html report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// htmlFailedTests lists the failed tests ordered by category, keeping the
+// order of the tests within a category.
+func htmlFailedTests(failedTests FailedTestsMap, reasons map[string]string, known []KnownFailure) []htmlFailedTest {
+	issues := make(map[string]string)
+	for _, failure := range known {
+		issues[failure.Name] = failure.Issue
+	}
+
+	var tests []htmlFailedTest
+	for _, cat := range sortedKeys(failedTests) {
+		for _, testName := range failedTests[cat] {
+			issue, isKnown := issues[testName]
+			tests = append(tests, htmlFailedTest{Category: cat, Name: testName, Reason: reasons[testName], Known: isKnown, Issue: issue})
+		}
+	}
+	return tests
+}
+
+// htmlSkipReasons lists the skip reasons, most frequent first.
+func htmlSkipReasons(skippedByReason map[string]int) []htmlSkipReason {
+	reasons := make([]htmlSkipReason, 0, len(skippedByReason))
+	for _, reason := range sortedKeys(skippedByReason) {
+		reasons = append(reasons, htmlSkipReason{Reason: reason, Count: skippedByReason[reason]})
+	}
+	sort.SliceStable(reasons, func(i, j int) bool {
+		return reasons[i].Count > reasons[j].Count
+	})
+	return reasons
+}
+
+// statusClass returns the CSS class the status of a suite is shown with.
+// Suites without a known status are colored by their failures.
+func statusClass(status junit.Status, failures int) string {
+	switch status {
+	case junit.StatusCompleted:
+		return "status-ok"
+	case junit.StatusCompletedWithFailures, junit.StatusSetupFailed:
+		return "status-failed"
+	case "":
+		if failures > 0 {
+			return "status-failed"
+		}
+		return "status-ok"
+	}
+	return "status-warning"
+}
+
+// verdictClass returns the CSS class the verdict of the policy is shown with.
+func verdictClass(verdict Verdict) string {
+	switch verdict {
+	case VerdictPassed:
+		return "status-ok"
+	case VerdictFailed:
+		return "status-failed"
+	}
+	return "status-warning"
+}
@@ -0,0 +1,74 @@
+package result_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func TestRendersHTMLReport(t *testing.T) {
+	t.Setenv("TIMESTAMP", "20261016-120000")
+
+	html, err := goldenResult().HTML(map[string]result.SuiteLinks{
+		"compute": {Log: "compute/compute-log.txt", K8sReporter: "compute/k8s-reporter"},
+		"ssp":     {Log: "ssp/ssp-log.txt"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error rendering the HTML report: %v", err)
+	}
+	report := string(html)
+
+	for _, expected := range []string{
+		"<p class=\"meta\">Execution from 20261016-120000</p>",
+		"<td><a href=\"#suite-compute\">compute</a></td>",
+		"<td><span class=\"status status-failed\">completed-with-failures</span></td>",
+		"<td><span class=\"status status-failed\">setup-failed</span></td>",
+		"<td><span class=\"status status-warning\">not-started</span></td>",
+		"<td><span class=\"status status-warning\">interrupted (incomplete)</span></td>",
+		"<a href=\"compute/compute-log.txt\">log</a><a href=\"compute/k8s-reporter\">k8s-reporter</a>",
+		"<a href=\"ssp/ssp-log.txt\">log</a>",
+		"<h2 id=\"suite-tier2\">tier2</h2>",
+		"<div class=\"warning\">Incomplete results recovered from the suite log: 3 of 4 tests completed</div>",
+		"<summary>[crit:high][level:system] VM Live Migration [test_id:1783]should be migrated</summary>\n<pre>Timed out after 180.001s.</pre>",
+		"<summary>[virt/node] test_cpu_sockets</summary>",
+		"<tr><td>requires in-place hotplug NICs</td><td class=\"num\">1</td></tr>",
+		"<tr><td>3m0s</td><td>compute</td><td>[crit:high][level:system] VM Live Migration [test_id:1783]should be migrated</td></tr>",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("expected the report to contain %q, got:\n%s", expected, report)
+		}
+	}
+
+	externalAsset := regexp.MustCompile(`<(?:script|link|img)\b[^>]*\b(?:src|href)=`)
+	if externalAsset.MatchString(report) {
+		t.Error("expected the report not to load any external asset")
+	}
+}
+
+func TestHTMLReportEscapesTestOutput(t *testing.T) {
+	res := result.New(map[string]junit.TestSuite{
+		"compute": {
+			Tests:    1,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "<script>alert(1)</script>", Classname: "Tests Suite", Failure: &junit.Outcome{Message: "expected <nil> & got \"x\""}},
+			},
+		},
+	})
+
+	html, err := res.HTML(nil)
+	if err != nil {
+		t.Fatalf("unexpected error rendering the HTML report: %v", err)
+	}
+	report := string(html)
+
+	if strings.Contains(report, "<script>") {
+		t.Errorf("expected test names to be escaped, got:\n%s", report)
+	}
+	if !strings.Contains(report, "<pre>expected &lt;nil&gt; &amp; got &#34;x&#34;</pre>") {
+		t.Errorf("expected the failure reason to be escaped, got:\n%s", report)
+	}
+}
@@ -0,0 +1,164 @@
+package result
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// KnownIssue is an entry of a known-issues catalog that ties failing tests to
+// a tracked bug, or of a skip list of the compute suite, e.g. its quarantine
+// list, that excludes tests from the run.
+type KnownIssue struct {
+	// ID matches the test whose full name is the ID, or whose name ends with
+	// it after a space or a tag, e.g. the text of a Ginkgo spec without its
+	// containers. An ID of the form "test_id:1783" or "rfe_id:393" only
+	// matches the tests tagged with it.
+	ID string `json:"id,omitempty"`
+	// Regex matches the tests whose name matches the regular expression.
+	Regex  string `json:"regex,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// Issue links the bug that tracks the failure. When empty, the first
+	// URL found in Reason is used.
+	Issue string `json:"issue,omitempty"`
+
+	regex *regexp.Regexp
+	// tag and tagValue hold the tag an ID of the form "test_id:1783" matches.
+	tag      string
+	tagValue string
+}
+
+// KnownIssues is a known-issues catalog.
+type KnownIssues []KnownIssue
+
+// KnownFailure is a failed test that matches a known issue.
+type KnownFailure struct {
+	Name   string `json:"name"`
+	Issue  string `json:"issue,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+var (
+	issueURLPattern = regexp.MustCompile(`https?://[^\s()]+`)
+	idTagPattern    = regexp.MustCompile(`^(test_id|rfe_id):\s*(\S+)$`)
+)
+
+// LoadKnownIssues reads and merges the known-issues catalogs stored as JSON
+// or YAML lists in fileNames.
+func LoadKnownIssues(fileNames ...string) (KnownIssues, error) {
+	var issues KnownIssues
+	for _, fileName := range fileNames {
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read known issues file %s; %w", fileName, err)
+		}
+
+		var loaded KnownIssues
+		if err := yaml.UnmarshalStrict(data, &loaded); err != nil {
+			return nil, fmt.Errorf("failed to parse known issues file %s; %w", fileName, err)
+		}
+
+		for i := range loaded {
+			if err := loaded[i].compile(); err != nil {
+				return nil, fmt.Errorf("invalid known issues file %s; %w", fileName, err)
+			}
+		}
+		issues = append(issues, loaded...)
+	}
+	return issues, nil
+}
+
+func (k *KnownIssue) compile() error {
+	switch {
+	case k.ID == "" && k.Regex == "":
+		return fmt.Errorf("known issue %q has neither an id nor a regex", k.Reason)
+	case k.ID != "" && k.Regex != "":
+		return fmt.Errorf("known issue %q has both an id and a regex", k.ID)
+	case k.Regex != "":
+		regex, err := regexp.Compile(k.Regex)
+		if err != nil {
+			return fmt.Errorf("known issue has an invalid regex %q; %w", k.Regex, err)
+		}
+		k.regex = regex
+	case idTagPattern.MatchString(k.ID):
+		match := idTagPattern.FindStringSubmatch(k.ID)
+		k.tag = match[1]
+		k.tagValue = match[2]
+	}
+
+	if k.Issue == "" {
+		k.Issue = strings.TrimRight(issueURLPattern.FindString(k.Reason), ".,;")
+	}
+	return nil
+}
+
+// match returns the first known issue that matches a test, or nil.
+func (k KnownIssues) match(testName string, testIDs []string) *KnownIssue {
+	for i := range k {
+		issue := &k[i]
+		switch {
+		case issue.regex != nil:
+			if issue.regex.MatchString(testName) {
+				return issue
+			}
+		case issue.tag != "":
+			if issue.tag == "test_id" && slices.Contains(testIDs, issue.tagValue) ||
+				strings.Contains(testName, "["+issue.tag+":"+issue.tagValue+"]") {
+				return issue
+			}
+		case matchesFullID(testName, issue.ID):
+			return issue
+		}
+	}
+	return nil
+}
+
+// matchesFullID reports whether a test name is id, or ends with it right
+// after a space or a "[tag]", so that an id does not match a test whose name
+// merely contains it.
+func matchesFullID(testName, id string) bool {
+	if testName == id {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(testName, id)
+	return ok && (strings.HasSuffix(prefix, " ") || strings.HasSuffix(prefix, "]"))
+}
+
+// FlagKnownFailures splits the failed tests of every suite into the ones
+// that match a known issue of the catalog and new ones.
+func (r *Result) FlagKnownFailures(issues KnownIssues) {
+	r.Summary.KnownFailures = 0
+	r.Summary.NewFailures = 0
+
+	for sig, sigRes := range r.SigMap {
+		testIDs := make(map[string][]string)
+		for id, testName := range sigRes.FailedTestIDs {
+			testIDs[testName] = append(testIDs[testName], id)
+		}
+
+		sigRes.KnownFailures = nil
+		sigRes.NewFailures = nil
+		for _, cat := range sortedKeys(sigRes.FailedTests) {
+			for _, testName := range sigRes.FailedTests[cat] {
+				issue := issues.match(testName, testIDs[testName])
+				if issue == nil {
+					sigRes.NewFailures = append(sigRes.NewFailures, testName)
+					continue
+				}
+				sigRes.KnownFailures = append(sigRes.KnownFailures, KnownFailure{
+					Name:   testName,
+					Issue:  issue.Issue,
+					Reason: trimReason(issue.Reason),
+				})
+			}
+		}
+
+		r.SigMap[sig] = sigRes
+		r.Summary.KnownFailures += len(sigRes.KnownFailures)
+		r.Summary.NewFailures += len(sigRes.NewFailures)
+	}
+}
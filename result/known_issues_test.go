@@ -0,0 +1,129 @@
+package result_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func writeKnownIssues(t *testing.T, content string) string {
+	t.Helper()
+
+	fileName := filepath.Join(t.TempDir(), "known_issues.json")
+	if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write known issues: %v", err)
+	}
+	return fileName
+}
+
+func TestFlagsKnownFailures(t *testing.T) {
+	fileName := writeKnownIssues(t, `[
+  {"id": "test_id:1783", "reason": "Bulk Quarantine - tracked in https://issues.redhat.com/browse/CNV-45625."},
+  {"id": "should apply flavor to CPU", "issue": "https://issues.redhat.com/browse/CNV-19162"},
+  {"id": "should boot", "issue": "https://issues.redhat.com/browse/CNV-1"},
+  {"id": "rfe_id: 393", "issue": "https://issues.redhat.com/browse/CNV-393"},
+  {"regex": "^test_hotplug_.*\\[rwx\\]$", "reason": "RWX hotplug is broken"}
+]`)
+	issues, err := result.LoadKnownIssues(fileName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	res := result.New(map[string]junit.TestSuite{
+		"compute": {
+			Tests:    6,
+			Failures: 6,
+			TestCases: []junit.TestCase{
+				{Name: "[test_id:1783] should migrate", Metadata: junit.Metadata{TestIDs: []string{"1783"}}, Failure: &junit.Outcome{}},
+				{Name: "[test_id:17830] should not match the prefix of a test_id", Metadata: junit.Metadata{TestIDs: []string{"17830"}}, Failure: &junit.Outcome{}},
+				{Name: "[sig-compute]Instancetype should apply flavor to CPU", Failure: &junit.Outcome{}},
+				{Name: "[rfe_id:393][sig-compute] should migrate twice", Failure: &junit.Outcome{}},
+				{Name: "should boot twice", Failure: &junit.Outcome{}},
+				{Name: "Windows VM should booted", Failure: &junit.Outcome{}},
+			},
+		},
+		"storage": {
+			Tests:    2,
+			Failures: 2,
+			TestCases: []junit.TestCase{
+				{Name: "test_hotplug_disk[rwx]", Classname: "tests.storage.test_hotplug", Failure: &junit.Outcome{}},
+				{Name: "test_hotplug_disk[rwo]", Classname: "tests.storage.test_hotplug", Failure: &junit.Outcome{}},
+			},
+		},
+	})
+
+	res.FlagKnownFailures(issues)
+
+	compute := res.SigMap["compute"]
+	expectedKnown := []result.KnownFailure{
+		{Name: "[test_id:1783] should migrate", Issue: "https://issues.redhat.com/browse/CNV-45625",
+			Reason: "Bulk Quarantine - tracked in https://issues.redhat.com/browse/CNV-45625."},
+		{Name: "[sig-compute]Instancetype should apply flavor to CPU", Issue: "https://issues.redhat.com/browse/CNV-19162"},
+		{Name: "[rfe_id:393][sig-compute] should migrate twice", Issue: "https://issues.redhat.com/browse/CNV-393"},
+	}
+	if !reflect.DeepEqual(compute.KnownFailures, expectedKnown) {
+		t.Errorf("expected known failures %+v, got %+v", expectedKnown, compute.KnownFailures)
+	}
+	expectedNew := []string{"[test_id:17830] should not match the prefix of a test_id", "should boot twice", "Windows VM should booted"}
+	if !reflect.DeepEqual(compute.NewFailures, expectedNew) {
+		t.Errorf("expected new failures %v, got %v", expectedNew, compute.NewFailures)
+	}
+
+	storage := res.SigMap["storage"]
+	if len(storage.KnownFailures) != 1 || storage.KnownFailures[0].Name != "test_hotplug_disk[rwx]" || storage.KnownFailures[0].Issue != "" {
+		t.Errorf("unexpected storage known failures: %+v", storage.KnownFailures)
+	}
+	if !reflect.DeepEqual(storage.NewFailures, []string{"test_hotplug_disk[rwo]"}) {
+		t.Errorf("unexpected storage new failures: %v", storage.NewFailures)
+	}
+
+	if res.Summary.KnownFailures != 4 || res.Summary.NewFailures != 4 {
+		t.Errorf("expected 4 known and 4 new failures, got %d and %d", res.Summary.KnownFailures, res.Summary.NewFailures)
+	}
+
+	output := res.String()
+	for _, expected := range []string{
+		"Known Failures:\n  - [test_id:1783] should migrate\n    Issue: https://issues.redhat.com/browse/CNV-45625\n",
+		"Known Failures:\n  - test_hotplug_disk[rwx]\n    Reason: RWX hotplug is broken\n",
+		"Total Known Failures: 4\nTotal New Failures: 4\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestLoadsSkipListsOfTheComputeSuite(t *testing.T) {
+	issues, err := result.LoadKnownIssues("../scripts/kubevirt/config/quarantined_tests.json", "../scripts/kubevirt/config/dont_run_tests.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) == 0 {
+		t.Fatal("expected the skip lists to hold entries")
+	}
+}
+
+func TestLoadKnownIssuesErrors(t *testing.T) {
+	for name, content := range map[string]string{
+		"no matcher":    `[{"reason": "broken"}]`,
+		"both matchers": `[{"id": "a", "regex": "b"}]`,
+		"invalid regex": `[{"regex": "("}]`,
+		"unknown field": `[{"name": "a"}]`,
+		"not a list":    `{"id": "a"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := result.LoadKnownIssues(writeKnownIssues(t, content)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	if _, err := result.LoadKnownIssues("/does/not/exist.json"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,358 @@
+package result
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Markdown renders the result as a Markdown report: an overview table of all
+// suites followed by one section per suite with its failed, flaky, skipped
+// and slowest tests.
+func (r Result) Markdown() string {
+	sb := strings.Builder{}
+
+	sb.WriteString("# Validation Checkup Results\n\n")
+	if timestamp := os.Getenv("TIMESTAMP"); timestamp != "" {
+		sb.WriteString(fmt.Sprintf("Execution from %s\n\n", timestamp))
+	}
+
+	if r.SetupFailure && len(r.SigMap) == 0 {
+		sb.WriteString("> **Error:** No tests were executed. One or more test suites failed during setup.\n")
+		sb.WriteString("> Check the test logs for details.\n")
+		if len(r.Summary.SuiteStatuses) > 0 {
+			sb.WriteString("\n")
+			writeMarkdownOverview(&sb, r)
+		}
+		return sb.String()
+	}
+
+	sb.WriteString("## Summary\n\n")
+	if r.Summary.Filter != "" {
+		sb.WriteString(fmt.Sprintf("Filter: `%s`\n\n", r.Summary.Filter))
+	}
+	if r.Summary.Verdict != "" {
+		sb.WriteString(fmt.Sprintf("Verdict: **%s**\n\n", r.Summary.Verdict))
+		for _, reason := range r.Summary.VerdictReasons {
+			sb.WriteString(fmt.Sprintf("- %s\n", reason))
+		}
+		if len(r.Summary.VerdictReasons) > 0 {
+			sb.WriteString("\n")
+		}
+	}
+	if r.Summary.Readiness != nil {
+		sb.WriteString(fmt.Sprintf("Readiness Score: **%g%%**", r.Summary.Readiness.Score))
+		if r.Summary.Readiness.Partial {
+			sb.WriteString(" (partial: the suites that failed during setup are not scored)")
+		}
+		sb.WriteString("\n\n")
+	}
+	if r.Summary.WallClock != "" {
+		sb.WriteString(fmt.Sprintf("Wall-clock Duration: **%s** (tests: %s)\n\n", r.Summary.WallClock, orZero(r.Summary.TestTime)))
+	}
+	writeMarkdownOverview(&sb, r)
+
+	var warnings []string
+	if r.SetupFailure {
+		warnings = append(warnings, "Some test suites failed during setup and were not included in the summary.")
+	}
+	if len(r.Summary.IncompleteSuites) > 0 {
+		warnings = append(warnings, fmt.Sprintf("The results of some test suites are incomplete: %s.",
+			strings.Join(r.Summary.IncompleteSuites, ", ")))
+	}
+	for _, warning := range warnings {
+		sb.WriteString(fmt.Sprintf("\n> **Warning:** %s\n", warning))
+	}
+
+	if len(r.Summary.Phases) > 0 {
+		sb.WriteString("\n### Run Phases\n\n")
+		writeMarkdownPhases(&sb, r.Summary.Phases)
+	}
+
+	if len(r.Summary.ByCriticality) > 0 || len(r.Summary.ByLevel) > 0 {
+		sb.WriteString("\n### Tests by Criticality and Level\n\n")
+		writeMarkdownGroups(&sb, r.Summary.ByCriticality, r.Summary.ByLevel)
+	}
+
+	for _, sig := range sortedKeys(r.SigMap) {
+		writeMarkdownSuite(&sb, sig, r.SigMap[sig])
+	}
+
+	if len(r.Summary.FailureSignatures) > 0 {
+		sb.WriteString("\n## Top Failure Signatures\n\n")
+		sb.WriteString("| Tests | Suites | Signature | Examples |\n")
+		sb.WriteString("|---:|---|---|---|\n")
+		for _, signature := range r.Summary.FailureSignatures {
+			sb.WriteString(fmt.Sprintf("| %d | %s | `%s` | %s |\n", signature.Count, strings.Join(signature.Suites, ", "),
+				markdownCell(strings.ReplaceAll(signature.Signature, "`", "'")), markdownCell(strings.Join(signature.Examples, "<br>"))))
+		}
+	}
+
+	if len(r.Summary.SlowestTests) > 0 {
+		sb.WriteString("\n## Slowest Tests\n\n")
+		writeMarkdownSlowestTests(&sb, r.Summary.SlowestTests)
+	}
+
+	if r.Comparison != nil {
+		writeMarkdownComparison(&sb, r.Comparison)
+	}
+
+	return sb.String()
+}
+
+// writeMarkdownComparison writes the count differences with the baseline as
+// a table, followed by the tests whose outcome changed.
+func writeMarkdownComparison(sb *strings.Builder, c *Comparison) {
+	sb.WriteString(fmt.Sprintf("\n## Comparison with Baseline\n\nBaseline: `%s`\n", c.Baseline))
+	if c.BaselineFailuresOnly {
+		sb.WriteString("\n> **Note:** Only the failed tests of the baseline are known.\n")
+	}
+	if c.BaselineTruncated {
+		sb.WriteString("\n> **Note:** The failed tests of the baseline were truncated; the ones left out are reported as newly failing.\n")
+	}
+
+	sb.WriteString("\n| Suite | Run | Passed | Failed | Skipped | Newly Failing | Newly Passing | Disappeared |\n")
+	sb.WriteString("|---|---:|---:|---:|---:|---:|---:|---:|\n")
+	for _, sig := range sortedKeys(c.Suites) {
+		suite := c.Suites[sig]
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %d |\n", sig, markdownDeltas(suite.Deltas),
+			len(suite.NewlyFailing), len(suite.NewlyPassing), len(suite.Disappeared)))
+	}
+	sb.WriteString(fmt.Sprintf("| **Total** | %s | | | |\n", markdownDeltas(c.TotalDeltas)))
+
+	for _, sig := range sortedKeys(c.Suites) {
+		suite := c.Suites[sig]
+		for _, list := range []struct {
+			title string
+			tests []string
+		}{
+			{"Newly Failing", suite.NewlyFailing},
+			{"Newly Passing", suite.NewlyPassing},
+			{"Disappeared", suite.Disappeared},
+		} {
+			if len(list.tests) == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\n### %s: %s\n\n", sig, list.title))
+			for _, testName := range list.tests {
+				sb.WriteString(fmt.Sprintf("- %s\n", testName))
+			}
+		}
+	}
+}
+
+// writeMarkdownOverview writes a table with the counts of every suite and
+// the total counts. Suites left out of the results, e.g. because they failed
+// during setup, are listed with their status only.
+func writeMarkdownOverview(sb *strings.Builder, r Result) {
+	sb.WriteString("| Suite | Status | Run | Passed | Failed | Skipped | Flaky | Duration |\n")
+	sb.WriteString("|---|---|---:|---:|---:|---:|---:|---|\n")
+
+	sigs := sortedKeys(r.SigMap)
+	for _, sig := range sortedKeys(r.Summary.SuiteStatuses) {
+		if _, ok := r.SigMap[sig]; !ok {
+			sigs = append(sigs, sig)
+		}
+	}
+	sort.Strings(sigs)
+
+	for _, sig := range sigs {
+		sigRes, ok := r.SigMap[sig]
+		if !ok {
+			sb.WriteString(fmt.Sprintf("| %s | %s | - | - | - | - | - | - |\n", sig, r.Summary.SuiteStatuses[sig]))
+			continue
+		}
+		status := string(sigRes.Status)
+		if sigRes.Incomplete {
+			status = strings.TrimSpace(status + " (incomplete)")
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %d | %d | %d | %s |\n",
+			sig, status, sigRes.Run, sigRes.Passed, sigRes.Failures, sigRes.Skipped, sigRes.Flaky, sigRes.Duration))
+	}
+
+	sb.WriteString(fmt.Sprintf("| **Total** | | **%d** | **%d** | **%d** | **%d** | **%d** | |\n",
+		r.Summary.Run, r.Summary.Passed, r.Summary.Failed, r.Summary.Skipped, r.Summary.Flaky))
+}
+
+func writeMarkdownSuite(sb *strings.Builder, sig string, sigRes Sig) {
+	sb.WriteString(fmt.Sprintf("\n## %s\n", sig))
+
+	if sigRes.Incomplete {
+		sb.WriteString(fmt.Sprintf("\n> **Warning:** %s\n", strings.TrimPrefix(incompleteWarning(sigRes), "WARNING: ")))
+	}
+
+	if fields := sigRes.Metadata.runSettings(); len(fields) > 0 {
+		sb.WriteString("\n")
+		for _, field := range fields {
+			sb.WriteString(fmt.Sprintf("- %s: `%s`\n", field.Label, field.Value))
+		}
+	}
+
+	if len(sigRes.SubSuites) > 0 {
+		sb.WriteString("\n### Sub-suites\n\n")
+		sb.WriteString("| Sub-suite | Run | Passed | Failed | Skipped | Duration |\n")
+		sb.WriteString("|---|---:|---:|---:|---:|---|\n")
+		for _, subSuite := range sigRes.SubSuites {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %s |\n", markdownCell(subSuite.Name),
+				subSuite.Run, subSuite.Passed, subSuite.Failures, subSuite.Skipped, subSuite.Duration))
+		}
+	}
+
+	if len(sigRes.Phases) > 0 {
+		sb.WriteString("\n### Phases\n\n")
+		if sigRes.WallClock != "" {
+			sb.WriteString(fmt.Sprintf("Wall-clock Duration: **%s** (tests: %s)\n\n", sigRes.WallClock, orZero(sigRes.TestTime)))
+		}
+		writeMarkdownPhases(sb, sigRes.Phases)
+	}
+
+	if len(sigRes.ByCriticality) > 0 || len(sigRes.ByLevel) > 0 {
+		sb.WriteString("\n### Tests by Criticality and Level\n\n")
+		writeMarkdownGroups(sb, sigRes.ByCriticality, sigRes.ByLevel)
+	}
+
+	if len(sigRes.FailedTests) > 0 {
+		sb.WriteString("\n### Failed Tests\n\n")
+		writeMarkdownFailedTests(sb, sigRes.FailedTests, sigRes.FailureReasons)
+	}
+
+	if len(sigRes.KnownFailures) > 0 {
+		sb.WriteString("\n### Known Failures\n\n")
+		for _, failure := range sigRes.KnownFailures {
+			switch {
+			case failure.Issue != "":
+				sb.WriteString(fmt.Sprintf("- %s: %s\n", failure.Name, failure.Issue))
+			case failure.Reason != "":
+				sb.WriteString(fmt.Sprintf("- %s: %s\n", failure.Name, failure.Reason))
+			default:
+				sb.WriteString(fmt.Sprintf("- %s\n", failure.Name))
+			}
+		}
+	}
+
+	if len(sigRes.FlakyTests) > 0 {
+		sb.WriteString("\n### Flaky Tests (passed on retry)\n\n")
+		for _, testName := range sigRes.FlakyTests {
+			sb.WriteString(fmt.Sprintf("- %s\n", testName))
+		}
+	}
+
+	if len(sigRes.SkippedByReason) > 0 {
+		sb.WriteString("\n### Skipped by Reason\n\n")
+		sb.WriteString("| Reason | Tests |\n")
+		sb.WriteString("|---|---:|\n")
+		reasons := sortedKeys(sigRes.SkippedByReason)
+		sort.SliceStable(reasons, func(i, j int) bool {
+			return sigRes.SkippedByReason[reasons[i]] > sigRes.SkippedByReason[reasons[j]]
+		})
+		for _, reason := range reasons {
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", markdownCell(reason), sigRes.SkippedByReason[reason]))
+		}
+	}
+
+	if len(sigRes.SlowestTests) > 0 {
+		sb.WriteString("\n### Slowest Tests\n\n")
+		writeMarkdownSlowestTests(sb, sigRes.SlowestTests)
+	}
+}
+
+// writeMarkdownPhases writes a timeline table of the phases of a run.
+func writeMarkdownPhases(sb *strings.Builder, phases []Phase) {
+	sb.WriteString("| Phase | Start | End | Duration |\n")
+	sb.WriteString("|---|---|---|---|\n")
+	for _, phase := range phases {
+		end, duration := phase.End, phase.Duration
+		if end == "" {
+			end, duration = "-", "did not end"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", markdownCell(phase.Name), phase.Start, end, duration))
+	}
+}
+
+// orZero returns the duration, or "0s" when it is empty.
+func orZero(duration string) string {
+	if duration == "" {
+		return "0s"
+	}
+	return duration
+}
+
+// writeMarkdownGroups writes a table with the counts per crit and level tag.
+func writeMarkdownGroups(sb *strings.Builder, byCrit, byLevel map[string]TestCounts) {
+	sb.WriteString("| Tag | Run | Passed | Failed | Skipped |\n")
+	sb.WriteString("|---|---:|---:|---:|---:|\n")
+	for _, group := range []struct {
+		tag    string
+		values []string
+		counts map[string]TestCounts
+	}{
+		{"crit", sortedCrits(byCrit), byCrit},
+		{"level", sortedKeys(byLevel), byLevel},
+	} {
+		for _, value := range group.values {
+			counts := group.counts[value]
+			sb.WriteString(fmt.Sprintf("| %s:%s | %d | %d | %d | %d |\n",
+				group.tag, value, counts.Run, counts.Passed, counts.Failures, counts.Skipped))
+		}
+	}
+}
+
+// writeMarkdownFailedTests writes the failed tests as a list, grouped under
+// their category when they have one, with the failure reason as a code span.
+func writeMarkdownFailedTests(sb *strings.Builder, failedTests FailedTestsMap, reasons map[string]string) {
+	writeTests := func(testNames []string, indent string) {
+		for _, testName := range testNames {
+			sb.WriteString(fmt.Sprintf("%s- %s\n", indent, testName))
+			if reason, ok := reasons[testName]; ok {
+				sb.WriteString(fmt.Sprintf("%s  - Reason: `%s`\n", indent, strings.ReplaceAll(reason, "`", "'")))
+			}
+		}
+	}
+
+	if failedTests.isFlat() {
+		writeTests(failedTests[""], "")
+		return
+	}
+
+	for _, cat := range sortedKeys(failedTests) {
+		label := cat
+		if label == "" {
+			label = "uncategorized"
+		}
+		sb.WriteString(fmt.Sprintf("- **%s**\n", label))
+		writeTests(failedTests[cat], "  ")
+	}
+}
+
+// writeMarkdownSlowestTests writes the slowest tests as a table, with a suite
+// column for the tests of the whole run.
+func writeMarkdownSlowestTests(sb *strings.Builder, tests []TestDuration) {
+	withSuite := len(tests) > 0 && tests[0].Suite != ""
+	if withSuite {
+		sb.WriteString("| Duration | Suite | Test |\n")
+		sb.WriteString("|---|---|---|\n")
+	} else {
+		sb.WriteString("| Duration | Test |\n")
+		sb.WriteString("|---|---|\n")
+	}
+	for _, test := range tests {
+		if withSuite {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", test.Duration, test.Suite, markdownCell(test.Name)))
+		} else {
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", test.Duration, markdownCell(test.Name)))
+		}
+	}
+}
+
+// markdownDeltas formats count differences as the run, passed, failed and
+// skipped cells of a table row.
+func markdownDeltas(deltas TestCounts) string {
+	return fmt.Sprintf("%s | %s | %s | %s",
+		signedCount(deltas.Run), signedCount(deltas.Passed), signedCount(deltas.Failures), signedCount(deltas.Skipped))
+}
+
+// markdownCell escapes the characters that would break a Markdown table cell.
+func markdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
@@ -0,0 +1,133 @@
+package result
+
+import (
+	"sort"
+
+	"junitparser/junit_parser/junit"
+)
+
+// TestCounts holds the number of tests run, passed, failed and skipped in a group of tests.
+type TestCounts struct {
+	Run      int `json:"tests_run"`
+	Passed   int `json:"tests_passed"`
+	Failures int `json:"tests_failures"`
+	Skipped  int `json:"tests_skipped"`
+}
+
+// AllPassed reports whether at least one test of the group ran and none of them failed.
+func (c TestCounts) AllPassed() bool {
+	return c.Run > 0 && c.Failures == 0
+}
+
+func (c *TestCounts) add(testCase junit.TestCase) {
+	switch {
+	case testCase.IsSkipped():
+		c.Skipped++
+		return
+	case testCase.IsFailed():
+		c.Failures++
+	default:
+		c.Passed++
+	}
+	c.Run++
+}
+
+func (c *TestCounts) merge(other TestCounts) {
+	c.Run += other.Run
+	c.Passed += other.Passed
+	c.Failures += other.Failures
+	c.Skipped += other.Skipped
+}
+
+// critRank orders the KubeVirt criticality values from most to least critical.
+var critRank = map[string]int{
+	"blocker":  0,
+	"critical": 1,
+	"high":     2,
+	"medium":   3,
+	"low":      4,
+}
+
+// groupCounts counts the test cases per value of a metadata field. Test
+// cases without a value are not counted. Returns nil when no test case has a
+// value, so that suites without tags do not get an empty section.
+func groupCounts(testCases []junit.TestCase, field func(junit.Metadata) string) map[string]TestCounts {
+	var groups map[string]TestCounts
+	for _, testCase := range testCases {
+		value := field(testCase.Metadata)
+		if value == "" {
+			continue
+		}
+		if groups == nil {
+			groups = make(map[string]TestCounts)
+		}
+		counts := groups[value]
+		counts.add(testCase)
+		groups[value] = counts
+	}
+	return groups
+}
+
+func critOf(md junit.Metadata) string  { return md.Crit }
+func levelOf(md junit.Metadata) string { return md.Level }
+
+// mergeGroups adds the counts of src to dst, allocating dst when needed.
+func mergeGroups(dst, src map[string]TestCounts) map[string]TestCounts {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]TestCounts)
+	}
+	for value, counts := range src {
+		total := dst[value]
+		total.merge(counts)
+		dst[value] = total
+	}
+	return dst
+}
+
+// failedTestIDs maps the test_id of every failed test case to its name.
+// Returns nil when no failed test case carries a test_id.
+func failedTestIDs(testCases []junit.TestCase) map[string]string {
+	var ids map[string]string
+	for _, testCase := range testCases {
+		if !testCase.IsFailed() {
+			continue
+		}
+		for _, id := range testCase.Metadata.TestIDs {
+			if ids == nil {
+				ids = make(map[string]string)
+			}
+			ids[id] = testCase.Name
+		}
+	}
+	return ids
+}
+
+// sortedCrits returns the criticality values of groups, most critical first.
+// Unknown values are sorted alphabetically after the known ones.
+func sortedCrits(groups map[string]TestCounts) []string {
+	values := sortedKeys(groups)
+	sort.SliceStable(values, func(i, j int) bool {
+		ri, iKnown := critRank[values[i]]
+		rj, jKnown := critRank[values[j]]
+		switch {
+		case iKnown && jKnown:
+			return ri < rj
+		case iKnown != jKnown:
+			return iKnown
+		}
+		return false
+	})
+	return values
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,198 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"junitparser/junit_parser/junit"
+)
+
+// Verdict is the overall outcome of a run, as judged by a Policy.
+type Verdict string
+
+const (
+	// VerdictPassed means that the run met every requirement of the policy.
+	VerdictPassed Verdict = "passed"
+	// VerdictFailed means that the run broke at least one requirement of the policy.
+	VerdictFailed Verdict = "failed"
+	// VerdictInconclusive means that the run did not break any requirement
+	// of the policy, but some of them could not be checked, e.g. because a
+	// suite did not run or a must-pass test was skipped.
+	VerdictInconclusive Verdict = "inconclusive"
+)
+
+// AllSuites is the key of the Policy suites entry that applies to every
+// suite without an entry of its own.
+const AllSuites = "*"
+
+// Policy holds the requirements a run has to meet to pass the validation.
+type Policy struct {
+	// Suites holds the requirements per suite name. The AllSuites entry
+	// applies to every suite of the run without an entry of its own.
+	Suites map[string]SuitePolicy `json:"suites,omitempty"`
+	// MustPass lists tests that have to run and pass, by test_id (e.g.
+	// "1783" or "test_id:1783") or by full test name.
+	MustPass []string `json:"must_pass,omitempty"`
+	// MaxFailuresByCriticality holds the maximum number of failed tests per
+	// crit tag value, e.g. {"critical": 0, "high": 2}.
+	MaxFailuresByCriticality map[string]int `json:"max_failures_by_criticality,omitempty"`
+}
+
+// SuitePolicy holds the requirements of a single suite.
+type SuitePolicy struct {
+	// MinPassRate is the minimum percentage of the tests run that have to
+	// pass, between 0 and 100.
+	MinPassRate float64 `json:"min_pass_rate"`
+}
+
+// LoadPolicy reads a policy stored as JSON or YAML in fileName.
+func LoadPolicy(fileName string) (Policy, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to read policy file %s; %w", fileName, err)
+	}
+
+	var policy Policy
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return Policy{}, fmt.Errorf("failed to parse policy file %s; %w", fileName, err)
+	}
+
+	if err := policy.validate(); err != nil {
+		return Policy{}, fmt.Errorf("invalid policy file %s; %w", fileName, err)
+	}
+
+	return policy, nil
+}
+
+func (p Policy) validate() error {
+	for sig, suite := range p.Suites {
+		if suite.MinPassRate < 0 || suite.MinPassRate > 100 {
+			return fmt.Errorf("suite %q has a min_pass_rate of %g; expected a percentage between 0 and 100", sig, suite.MinPassRate)
+		}
+	}
+	for _, test := range p.MustPass {
+		if strings.TrimSpace(test) == "" {
+			return errors.New("must_pass has an empty test")
+		}
+	}
+	for crit, maxFailures := range p.MaxFailuresByCriticality {
+		if maxFailures < 0 {
+			return fmt.Errorf("criticality %q has a negative maximum number of failures", crit)
+		}
+	}
+	return nil
+}
+
+// Evaluate judges the result by the policy and stores the verdict and its
+// reasons in the summary. junitResults holds the raw results the result was
+// built from; it is used to find the must-pass tests.
+func (r *Result) Evaluate(policy Policy, junitResults map[string]junit.TestSuite) {
+	var failures, unknowns []string
+
+	sigs := make(map[string]bool)
+	for sig := range r.SigMap {
+		sigs[sig] = true
+	}
+	for sig := range r.Summary.SuiteStatuses {
+		sigs[sig] = true
+	}
+	for sig := range policy.Suites {
+		if sig != AllSuites {
+			sigs[sig] = true
+		}
+	}
+
+	for _, sig := range sortedKeys(sigs) {
+		suite, ok := policy.Suites[sig]
+		if !ok {
+			suite, ok = policy.Suites[AllSuites]
+		}
+		if !ok {
+			continue
+		}
+
+		sigRes, ok := r.SigMap[sig]
+		switch {
+		case !ok && r.Summary.SuiteStatuses[sig] != "":
+			unknowns = append(unknowns, fmt.Sprintf("suite %s did not run any test (%s)", sig, r.Summary.SuiteStatuses[sig]))
+			continue
+		case !ok:
+			unknowns = append(unknowns, fmt.Sprintf("suite %s has no results", sig))
+			continue
+		case sigRes.Run == 0:
+			unknowns = append(unknowns, fmt.Sprintf("suite %s did not run any test", sig))
+			continue
+		}
+
+		passRate := float64(sigRes.Passed) * 100 / float64(sigRes.Run)
+		if passRate < suite.MinPassRate {
+			failures = append(failures, fmt.Sprintf("suite %s pass rate %s%% is below the minimum of %s%%",
+				sig, formatPercentage(passRate), formatPercentage(suite.MinPassRate)))
+		} else if sigRes.Incomplete {
+			unknowns = append(unknowns, fmt.Sprintf("suite %s has incomplete results", sig))
+		}
+	}
+
+	for _, test := range policy.MustPass {
+		switch mustPassOutcome(test, r.SigMap, junitResults) {
+		case outcomeFailed:
+			failures = append(failures, fmt.Sprintf("must-pass test %s failed", test))
+		case outcomeSkipped:
+			unknowns = append(unknowns, fmt.Sprintf("must-pass test %s did not run", test))
+		}
+	}
+
+	for _, crit := range sortedKeys(policy.MaxFailuresByCriticality) {
+		maxFailures := policy.MaxFailuresByCriticality[crit]
+		if failed := r.Summary.ByCriticality[crit].Failures; failed > maxFailures {
+			failures = append(failures, fmt.Sprintf("%d crit:%s tests failed, at most %d allowed", failed, crit, maxFailures))
+		}
+	}
+
+	switch {
+	case len(failures) > 0:
+		r.Summary.Verdict = VerdictFailed
+	case len(unknowns) > 0:
+		r.Summary.Verdict = VerdictInconclusive
+	default:
+		r.Summary.Verdict = VerdictPassed
+	}
+	r.Summary.VerdictReasons = append(failures, unknowns...)
+}
+
+// mustPassOutcome returns the outcome of a must-pass test in the suites of
+// the result: failed when any of its runs failed, passed when it ran and
+// passed, and skipped when it was skipped or not found.
+func mustPassOutcome(test string, sigMap SigMap, junitResults map[string]junit.TestSuite) testOutcome {
+	testID := strings.TrimPrefix(test, "test_id:")
+
+	outcome := outcomeSkipped
+	for sig := range sigMap {
+		for _, testCase := range junitResults[sig].TestCases {
+			if testCase.Name != test && !slices.Contains(testCase.Metadata.TestIDs, testID) {
+				continue
+			}
+			switch {
+			case testCase.IsFailed():
+				return outcomeFailed
+			case !testCase.IsSkipped():
+				outcome = outcomePassed
+			}
+		}
+	}
+	return outcome
+}
+
+// formatPercentage formats a percentage rounded down to one decimal, e.g.
+// "87.5" or "95", so that a rate just below a minimum is not shown as equal
+// to it.
+func formatPercentage(value float64) string {
+	return strconv.FormatFloat(math.Floor(value*10)/10, 'f', -1, 64)
+}
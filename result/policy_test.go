@@ -0,0 +1,186 @@
+package result_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func policyJUnitResults() map[string]junit.TestSuite {
+	return map[string]junit.TestSuite{
+		"compute": {
+			Tests:    4,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "[crit:high] test1", Metadata: junit.Metadata{TestIDs: []string{"1783"}, Crit: "high"}},
+				{Name: "[crit:high] test2", Metadata: junit.Metadata{TestIDs: []string{"1784"}, Crit: "high"}, Failure: &junit.Outcome{}},
+				{Name: "[crit:low] test3", Metadata: junit.Metadata{Crit: "low"}},
+				{Name: "test4"},
+				{Name: "test5", Skipped: &junit.Outcome{}},
+			},
+		},
+		"network": {
+			Tests:     1,
+			TestCases: []junit.TestCase{{Name: "test1"}},
+		},
+		"storage": {
+			Status:       junit.StatusSetupFailed,
+			SetupFailure: true,
+		},
+	}
+}
+
+func TestEvaluatesPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		policy          result.Policy
+		expectedVerdict result.Verdict
+		expectedReasons []string
+	}{
+		{
+			name:            "empty policy",
+			policy:          result.Policy{},
+			expectedVerdict: result.VerdictPassed,
+		},
+		{
+			name:            "pass rate met",
+			policy:          result.Policy{Suites: map[string]result.SuitePolicy{"compute": {MinPassRate: 75}, "network": {MinPassRate: 100}}},
+			expectedVerdict: result.VerdictPassed,
+		},
+		{
+			name:            "pass rate below the minimum",
+			policy:          result.Policy{Suites: map[string]result.SuitePolicy{"compute": {MinPassRate: 80}}},
+			expectedVerdict: result.VerdictFailed,
+			expectedReasons: []string{"suite compute pass rate 75% is below the minimum of 80%"},
+		},
+		{
+			name:            "default suite policy",
+			policy:          result.Policy{Suites: map[string]result.SuitePolicy{"*": {MinPassRate: 50}, "ssp": {MinPassRate: 50}}},
+			expectedVerdict: result.VerdictInconclusive,
+			expectedReasons: []string{"suite ssp has no results", "suite storage did not run any test (setup-failed)"},
+		},
+		{
+			name:            "must-pass tests passed",
+			policy:          result.Policy{MustPass: []string{"1783", "test_id:1783", "test4"}},
+			expectedVerdict: result.VerdictPassed,
+		},
+		{
+			name:            "must-pass tests failed or did not run",
+			policy:          result.Policy{MustPass: []string{"test_id:1784", "test5", "missing"}},
+			expectedVerdict: result.VerdictFailed,
+			expectedReasons: []string{"must-pass test test_id:1784 failed", "must-pass test test5 did not run", "must-pass test missing did not run"},
+		},
+		{
+			name:            "failures per criticality",
+			policy:          result.Policy{MaxFailuresByCriticality: map[string]int{"high": 0, "low": 0, "critical": 0}},
+			expectedVerdict: result.VerdictFailed,
+			expectedReasons: []string{"1 crit:high tests failed, at most 0 allowed"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			junitRes := policyJUnitResults()
+			res := result.New(junitRes)
+
+			res.Evaluate(tc.policy, junitRes)
+
+			if res.Summary.Verdict != tc.expectedVerdict {
+				t.Errorf("expected verdict %s, got %s", tc.expectedVerdict, res.Summary.Verdict)
+			}
+			if !reflect.DeepEqual(res.Summary.VerdictReasons, tc.expectedReasons) {
+				t.Errorf("expected reasons %q, got %q", tc.expectedReasons, res.Summary.VerdictReasons)
+			}
+		})
+	}
+}
+
+func TestReportsVerdict(t *testing.T) {
+	junitRes := policyJUnitResults()
+	res := result.New(junitRes)
+	res.Evaluate(result.Policy{Suites: map[string]result.SuitePolicy{"compute": {MinPassRate: 80}}}, junitRes)
+
+	output := res.String()
+	if !strings.Contains(output, "Verdict: failed\n  - suite compute pass rate 75% is below the minimum of 80%\n") {
+		t.Errorf("expected the output to contain the verdict, got:\n%s", output)
+	}
+
+	if markdown := res.Markdown(); !strings.Contains(markdown, "Verdict: **failed**\n\n- suite compute pass rate 75% is below the minimum of 80%\n") {
+		t.Errorf("expected the Markdown to contain the verdict, got:\n%s", markdown)
+	}
+
+	resYaml, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting result to YAML: %v", err)
+	}
+	if !strings.Contains(string(resYaml), "  verdict: failed\n  verdict_reasons:\n  - suite compute pass rate 75% is below the minimum of 80%\n") {
+		t.Errorf("expected the YAML to contain the verdict, got:\n%s", resYaml)
+	}
+
+	if withoutPolicy := result.New(junitRes).String(); strings.Contains(withoutPolicy, "Verdict:") {
+		t.Errorf("expected no verdict without a policy, got:\n%s", withoutPolicy)
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		content     string
+		expected    result.Policy
+		expectError bool
+	}{
+		{
+			name:    "yaml",
+			content: "suites:\n  \"*\":\n    min_pass_rate: 90\nmust_pass:\n- \"test_id:1783\"\nmax_failures_by_criticality:\n  critical: 0\n",
+			expected: result.Policy{
+				Suites:                   map[string]result.SuitePolicy{"*": {MinPassRate: 90}},
+				MustPass:                 []string{"test_id:1783"},
+				MaxFailuresByCriticality: map[string]int{"critical": 0},
+			},
+		},
+		{
+			name:     "json",
+			content:  `{"suites": {"compute": {"min_pass_rate": 95.5}}}`,
+			expected: result.Policy{Suites: map[string]result.SuitePolicy{"compute": {MinPassRate: 95.5}}},
+		},
+		{
+			name:        "unknown field",
+			content:     "suites:\n  compute:\n    min_pass_rates: 90\n",
+			expectError: true,
+		},
+		{
+			name:        "pass rate above 100",
+			content:     "suites:\n  compute:\n    min_pass_rate: 120\n",
+			expectError: true,
+		},
+		{
+			name:        "negative maximum of failures",
+			content:     "max_failures_by_criticality:\n  high: -1\n",
+			expectError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "policy.yaml")
+			if err := os.WriteFile(fileName, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("failed to write policy: %v", err)
+			}
+
+			policy, err := result.LoadPolicy(fileName)
+			if tc.expectError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(policy, tc.expected) {
+				t.Errorf("expected policy %+v, got %+v", tc.expected, policy)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package result
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"junitparser/junit_parser/junit"
+)
+
+// OtherValues is the key of the ScoreWeights entry that applies to the tests
+// whose tag has no entry of its own, including the tests without the tag.
+const OtherValues = "*"
+
+// ScoreWeights holds the weights of the tests in the readiness score, per
+// crit and level tag value. The weight of a test is the product of the
+// weights of its crit and its level.
+type ScoreWeights struct {
+	Crit  map[string]float64 `json:"crit,omitempty"`
+	Level map[string]float64 `json:"level,omitempty"`
+}
+
+// Readiness is the readiness score of a suite or of the whole run.
+type Readiness struct {
+	// Score is the percentage of the weight of the tests run that passed,
+	// rounded down to one decimal.
+	Score float64 `json:"score"`
+	// PassedWeight and RunWeight are the summed weights of the tests that
+	// passed and of the tests that ran.
+	PassedWeight float64 `json:"passed_weight"`
+	RunWeight    float64 `json:"run_weight"`
+	// Partial is set on the score of the run when suites that failed during
+	// setup were left out of it, as their tests are not known to have
+	// passed or failed.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// DefaultScoreWeights returns the weights the readiness score is computed
+// with when none are configured: a critical test weighs ten low ones, and a
+// system test two component ones.
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{
+		Crit: map[string]float64{
+			"blocker":   10,
+			"critical":  10,
+			"high":      5,
+			"medium":    2,
+			"low":       1,
+			OtherValues: 1,
+		},
+		Level: map[string]float64{
+			"system":    2,
+			"component": 1,
+			OtherValues: 1,
+		},
+	}
+}
+
+// LoadScoreWeights reads the weights stored as JSON or YAML in fileName. A
+// tag whose weights are not given keeps the default ones.
+func LoadScoreWeights(fileName string) (ScoreWeights, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return ScoreWeights{}, fmt.Errorf("failed to read score weights file %s; %w", fileName, err)
+	}
+
+	var loaded ScoreWeights
+	if err := yaml.UnmarshalStrict(data, &loaded); err != nil {
+		return ScoreWeights{}, fmt.Errorf("failed to parse score weights file %s; %w", fileName, err)
+	}
+
+	weights := DefaultScoreWeights()
+	for tag, values := range map[string]map[string]float64{"crit": loaded.Crit, "level": loaded.Level} {
+		for value, weight := range values {
+			if weight < 0 {
+				return ScoreWeights{}, fmt.Errorf("invalid score weights file %s; %s %q has a negative weight", fileName, tag, value)
+			}
+		}
+	}
+	if loaded.Crit != nil {
+		weights.Crit = lowerKeys(loaded.Crit)
+	}
+	if loaded.Level != nil {
+		weights.Level = lowerKeys(loaded.Level)
+	}
+	return weights, nil
+}
+
+// lowerKeys lowercases the tag values, like ParseMetadata does.
+func lowerKeys(values map[string]float64) map[string]float64 {
+	lowered := make(map[string]float64, len(values))
+	for value, weight := range values {
+		lowered[strings.ToLower(value)] = weight
+	}
+	return lowered
+}
+
+// weight returns the weight of a test with the given metadata.
+func (w ScoreWeights) weight(md junit.Metadata) float64 {
+	return tagWeight(w.Crit, md.Crit) * tagWeight(w.Level, md.Level)
+}
+
+// tagWeight returns the weight of a tag value, falling back to the
+// OtherValues entry and then to 1.
+func tagWeight(weights map[string]float64, value string) float64 {
+	if weight, ok := weights[value]; ok && value != "" {
+		return weight
+	}
+	if weight, ok := weights[OtherValues]; ok {
+		return weight
+	}
+	return 1
+}
+
+// ScoreReadiness computes the readiness score of every suite and of the whole
+// run, the share of the tests run that passed with every test weighted by its
+// criticality and level. Skipped tests do not count, and flaky tests count
+// as passed. A suite whose tests run weigh nothing gets no score.
+//
+// A suite that failed during setup ran none of its tests, so it is left out
+// of the score of the run, which is flagged as partial, just like Evaluate
+// finds the verdict of such a run inconclusive.
+func (r *Result) ScoreReadiness(weights ScoreWeights, junitResults map[string]junit.TestSuite) {
+	var total Readiness
+	for _, testSuite := range junitResults {
+		if testSuite.SetupFailure {
+			total.Partial = true
+		}
+	}
+
+	for sig, sigRes := range r.SigMap {
+		var readiness Readiness
+		for _, testCase := range junitResults[sig].TestCases {
+			if testCase.IsSkipped() {
+				continue
+			}
+			weight := weights.weight(testCase.Metadata)
+			readiness.RunWeight += weight
+			if !testCase.IsFailed() {
+				readiness.PassedWeight += weight
+			}
+		}
+
+		total.RunWeight += readiness.RunWeight
+		total.PassedWeight += readiness.PassedWeight
+		sigRes.Readiness = readiness.scored()
+		r.SigMap[sig] = sigRes
+	}
+	r.Summary.Readiness = total.scored()
+}
+
+// scored returns the readiness with its score computed, or nil when the
+// tests run weigh nothing.
+func (r Readiness) scored() *Readiness {
+	if r.RunWeight <= 0 {
+		return nil
+	}
+	r.Score = math.Floor(r.PassedWeight*1000/r.RunWeight) / 10
+	return &r
+}
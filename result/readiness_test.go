@@ -0,0 +1,131 @@
+package result_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func readinessJUnitResults() map[string]junit.TestSuite {
+	return map[string]junit.TestSuite{
+		"compute": {
+			Tests:    4,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "[crit:critical][level:system] migrate", Metadata: junit.Metadata{Crit: "critical", Level: "system"}},
+				{Name: "[crit:low][level:component] hotplug", Metadata: junit.Metadata{Crit: "low", Level: "component"}, Failure: &junit.Outcome{}},
+				{Name: "[crit:high] snapshot", Metadata: junit.Metadata{Crit: "high"}, Skipped: &junit.Outcome{}},
+				{Name: "untagged"},
+			},
+		},
+		"network": {
+			Tests:     1,
+			Failures:  1,
+			TestCases: []junit.TestCase{{Name: "[crit:high] bridge", Metadata: junit.Metadata{Crit: "high"}, Failure: &junit.Outcome{}}},
+		},
+		"storage": {
+			Tests:     1,
+			Skipped:   1,
+			TestCases: []junit.TestCase{{Name: "skipped", Skipped: &junit.Outcome{}}},
+		},
+	}
+}
+
+func TestLeavesSetupFailuresOutOfTheScore(t *testing.T) {
+	junitResults := readinessJUnitResults()
+	junitResults["ssp"] = junit.TestSuite{SetupFailure: true, Expected: 3}
+	junitResults["tier2"] = junit.TestSuite{
+		SetupFailure: true,
+		TestCases:    []junit.TestCase{{Name: "[BeforeSuite]", Metadata: junit.Metadata{Crit: "high"}, Failure: &junit.Outcome{}}},
+	}
+	res := result.New(junitResults)
+	res.ScoreReadiness(result.DefaultScoreWeights(), junitResults)
+
+	// Only the 27 of the other suites.
+	total := &result.Readiness{Score: 77.7, PassedWeight: 21, RunWeight: 27, Partial: true}
+	if !reflect.DeepEqual(res.Summary.Readiness, total) {
+		t.Errorf("expected total readiness %+v, got %+v", total, res.Summary.Readiness)
+	}
+	if _, ok := res.SigMap["ssp"]; ok {
+		t.Error("expected the setup failure of ssp to stay out of the suites")
+	}
+}
+
+func TestScoresReadinessWithDefaultWeights(t *testing.T) {
+	junitResults := readinessJUnitResults()
+	res := result.New(junitResults)
+	res.ScoreReadiness(result.DefaultScoreWeights(), junitResults)
+
+	// compute: critical/system 10*2 passed, low/component 1*1 failed,
+	// untagged 1 passed; network: high 5 failed.
+	expected := map[string]*result.Readiness{
+		"compute": {Score: 95.4, PassedWeight: 21, RunWeight: 22},
+		"network": {Score: 0, PassedWeight: 0, RunWeight: 5},
+		"storage": nil,
+	}
+	for sig, readiness := range expected {
+		if got := res.SigMap[sig].Readiness; !reflect.DeepEqual(got, readiness) {
+			t.Errorf("expected %s readiness %+v, got %+v", sig, readiness, got)
+		}
+	}
+
+	total := &result.Readiness{Score: 77.7, PassedWeight: 21, RunWeight: 27}
+	if !reflect.DeepEqual(res.Summary.Readiness, total) {
+		t.Errorf("expected total readiness %+v, got %+v", total, res.Summary.Readiness)
+	}
+}
+
+func TestLoadsScoreWeights(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "weights.yaml")
+	if err := os.WriteFile(fileName, []byte("crit:\n  High: 4\n  '*': 0\n"), 0644); err != nil {
+		t.Fatalf("failed to write weights: %v", err)
+	}
+
+	weights, err := result.LoadScoreWeights(fileName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(weights.Crit, map[string]float64{"high": 4, result.OtherValues: 0}) {
+		t.Errorf("expected the crit weights of the file, got %v", weights.Crit)
+	}
+	if !reflect.DeepEqual(weights.Level, result.DefaultScoreWeights().Level) {
+		t.Errorf("expected the default level weights, got %v", weights.Level)
+	}
+
+	junitResults := readinessJUnitResults()
+	res := result.New(junitResults)
+	res.ScoreReadiness(weights, junitResults)
+
+	// Only the high network test weighs anything.
+	if res.SigMap["compute"].Readiness != nil {
+		t.Errorf("expected no compute readiness, got %+v", res.SigMap["compute"].Readiness)
+	}
+	if readiness := res.Summary.Readiness; readiness == nil || readiness.Score != 0 || readiness.RunWeight != 4 {
+		t.Errorf("unexpected total readiness %+v", readiness)
+	}
+}
+
+func TestScoreWeightsErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"negative.yaml": "level:\n  system: -1\n",
+		"unknown.yaml":  "rfe_id:\n  '393': 2\n",
+		"invalid.yaml":  "crit: [",
+	} {
+		fileName := filepath.Join(dir, name)
+		if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write weights: %v", err)
+		}
+		if _, err := result.LoadScoreWeights(fileName); err == nil {
+			t.Errorf("expected an error for %s", name)
+		}
+	}
+
+	if _, err := result.LoadScoreWeights(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
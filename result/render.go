@@ -0,0 +1,64 @@
+package result
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Format is an output format the result can be rendered in.
+type Format string
+
+const (
+	// FormatText is the human-readable summary printed by String.
+	FormatText Format = "text"
+	// FormatJSON is the result as indented JSON, with the same keys as the
+	// ConfigMap.
+	FormatJSON Format = "json"
+	// FormatYAML is the result as YAML, as stored in the ConfigMap.
+	FormatYAML Format = "yaml"
+	// FormatMarkdown is a Markdown report, e.g. for a CI job summary or a
+	// pull request comment.
+	FormatMarkdown Format = "markdown"
+)
+
+// Formats lists the supported output formats.
+var Formats = []Format{FormatText, FormatJSON, FormatYAML, FormatMarkdown}
+
+// ParseFormat returns the output format with the given name. An empty name
+// selects FormatText.
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		return FormatText, nil
+	}
+	for _, format := range Formats {
+		if strings.EqualFold(name, string(format)) {
+			return format, nil
+		}
+	}
+
+	names := make([]string, 0, len(Formats))
+	for _, format := range Formats {
+		names = append(names, string(format))
+	}
+	return "", fmt.Errorf("unknown output format %q; supported formats are %s", name, strings.Join(names, ", "))
+}
+
+// Render renders the result in the given format.
+func (r Result) Render(format Format) ([]byte, error) {
+	switch format {
+	case FormatText:
+		return []byte(r.String()), nil
+	case FormatJSON:
+		resJson, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode result: %w", err)
+		}
+		return append(resJson, '\n'), nil
+	case FormatYAML:
+		return r.GetYaml()
+	case FormatMarkdown:
+		return []byte(r.Markdown()), nil
+	}
+	return nil, fmt.Errorf("unknown output format %q", format)
+}
@@ -0,0 +1,155 @@
+package result_test
+
+import (
+	"flag"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+// goldenResult builds a result covering most of the sections of the
+// renderers: failures with reasons, pytest categories, flaky and skipped
+// tests, tags, metadata, an incomplete suite and a suite that failed during
+// setup.
+func goldenResult() result.Result {
+	junitResults := map[string]junit.TestSuite{
+		"compute": {
+			Tests:    3,
+			Failures: 1,
+			Time:     1230,
+			Status:   junit.StatusCompletedWithFailures,
+			Phases: []junit.Phase{
+				{Name: "setup", Start: goldenTime(0), End: goldenTime(40)},
+				{Name: "tests", Start: goldenTime(40), End: goldenTime(1290)},
+				{Name: "namespace-cleanup", Start: goldenTime(1290), End: goldenTime(1305)},
+			},
+			Properties: []junit.Property{
+				{Name: "LabelFilter", Value: "!Windows"},
+				{Name: "RandomSeed", Value: "1712"},
+			},
+			TestCases: []junit.TestCase{
+				{
+					Name: "[crit:high][level:system] VM Live Migration [test_id:1783]should be migrated", Classname: "Tests Suite",
+					Time: 180.1, Failure: &junit.Outcome{Message: "Timed out after 180.001s."},
+					Metadata: junit.Metadata{TestIDs: []string{"1783"}, Crit: "high", Level: "system"},
+				},
+				{
+					Name: "[crit:high][level:system] VM Hotplug [test_id:1001]should plug a disk", Classname: "Tests Suite",
+					Time: 45.2, Attempts: 2,
+					Metadata: junit.Metadata{TestIDs: []string{"1001"}, Crit: "high", Level: "system"},
+				},
+				{
+					Name: "[crit:medium][level:component] VM Hotplug [test_id:1002]should plug a NIC", Classname: "Tests Suite",
+					Skipped:  &junit.Outcome{Message: "skipped - requires in-place hotplug NICs"},
+					Metadata: junit.Metadata{TestIDs: []string{"1002"}, Crit: "medium", Level: "component"},
+				},
+				{
+					Name: "VM Snapshot should restore", Classname: "Tests Suite",
+					Skipped: &junit.Outcome{Message: "skipped"},
+				},
+			},
+		},
+		"tier2": {
+			Tests:      3,
+			Failures:   1,
+			Skipped:    1,
+			Time:       95.5,
+			Status:     junit.StatusInterrupted,
+			Incomplete: true,
+			Expected:   4,
+			TestCases: []junit.TestCase{
+				{
+					Name: "test_cpu_sockets", Classname: "tests.virt.node.test_cpu.TestCPU",
+					Time: 60.5, Failure: &junit.Outcome{Message: "AssertionError: expected 2 sockets | got 1"},
+				},
+				{Name: "test_hotplug_disk[block]", Classname: "tests.storage.test_hotplug", Time: 35},
+				{
+					Name: "test_snapshot", Classname: "tests.storage.test_snapshot",
+					Skipped: &junit.Outcome{Type: "pytest.skip", Message: "storage class does not support RWX"},
+				},
+			},
+		},
+		"ssp": {
+			SetupFailure: true,
+			Status:       junit.StatusSetupFailed,
+		},
+	}
+
+	res := result.New(junitResults)
+	res.MarkNotStarted([]string{"compute", "ssp", "tier2", "network"})
+	res.ScoreReadiness(result.DefaultScoreWeights(), junitResults)
+	res.AddTimeline([]junit.Phase{
+		{Name: "windows-image-setup", Start: goldenTime(-600), End: goldenTime(-5)},
+		{Name: "dry-run-discovery", Start: goldenTime(-5)},
+	}, junitResults)
+	return res
+}
+
+// goldenTime returns the time the given number of seconds after the start
+// of the golden run.
+func goldenTime(seconds int) time.Time {
+	return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC).Add(time.Duration(seconds) * time.Second)
+}
+
+func TestRendersGoldenFiles(t *testing.T) {
+	t.Setenv("TIMESTAMP", "20261016-120000")
+
+	goldenFiles := map[result.Format]string{
+		result.FormatText:     "result.txt",
+		result.FormatJSON:     "result.json",
+		result.FormatYAML:     "result.yaml",
+		result.FormatMarkdown: "result.md",
+	}
+
+	for _, format := range result.Formats {
+		t.Run(string(format), func(t *testing.T) {
+			rendered, err := goldenResult().Render(format)
+			if err != nil {
+				t.Fatalf("unexpected error rendering %s: %v", format, err)
+			}
+
+			goldenFile := path.Join("testdata", "golden", goldenFiles[format])
+			if *update {
+				if err := os.WriteFile(goldenFile, rendered, 0644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+
+			expected, err := os.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if string(rendered) != string(expected) {
+				t.Errorf("%s output does not match %s (run with -update to regenerate):\n%s", format, goldenFile, rendered)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, expected := range map[string]result.Format{
+		"":         result.FormatText,
+		"text":     result.FormatText,
+		"JSON":     result.FormatJSON,
+		"yaml":     result.FormatYAML,
+		"markdown": result.FormatMarkdown,
+	} {
+		format, err := result.ParseFormat(name)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", name, err)
+		}
+		if format != expected {
+			t.Errorf("expected %q to be parsed as %q, got %q", name, expected, format)
+		}
+	}
+
+	if _, err := result.ParseFormat("html"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
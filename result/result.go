@@ -1,9 +1,11 @@
 package result
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -15,85 +17,164 @@ import (
 
 // Result represents the result of a test run, including a map of test suite results and a summary.
 type Result struct {
+	// APIVersion is the version of the result format. It is empty in the
+	// results written before it was introduced.
+	APIVersion   string  `json:"apiVersion,omitempty"`
 	SigMap       SigMap  `json:",omitempty,inline"`
 	Summary      Summary `json:"summary,omitempty"`
 	SetupFailure bool    `json:"-"`
+	// Comparison holds the differences with a baseline run, when one was
+	// given. It is stored under its own ConfigMap key.
+	Comparison *Comparison `json:"-"`
 }
 
 // New creates a new Result struct from the given map of JUnit test results.
 func New(junitResults map[string]junit.TestSuite) Result {
 	res := Result{
-		SigMap: make(SigMap),
+		APIVersion: APIVersion,
+		SigMap:     make(SigMap),
 	}
 
+	var allDurations []TestDuration
+
 	for sig, testSuite := range junitResults {
+		if testSuite.Status != "" {
+			if res.Summary.SuiteStatuses == nil {
+				res.Summary.SuiteStatuses = make(map[string]junit.Status)
+			}
+			res.Summary.SuiteStatuses[sig] = testSuite.Status
+		}
+
 		if testSuite.SetupFailure {
 			res.SetupFailure = true
 			fmt.Fprintf(os.Stderr, "Suite %q failed during setup (no tests were executed)\n", sig)
 			continue
 		}
 
-		// headerSkipped is the count from the testsuite XML attributes.
-		// Only this value should be used to adjust testsRun, because the
-		// header "tests" attribute includes skipped tests in pytest/SSP
-		// but excludes them in Ginkgo.
-		headerSkipped := testSuite.Skipped + testSuite.Disabled
-
-		// displaySkipped is what we show in the summary. When the header
-		// doesn't report skipped counts (Ginkgo), fall back to counting
-		// individual testcase elements so the report still shows the total.
-		displaySkipped := headerSkipped
-		if displaySkipped == 0 {
-			for _, testCase := range testSuite.TestCases {
-				if testCase.Skipped {
-					displaySkipped++
-				}
-			}
-		}
-
-		totalFailures := testSuite.Failures + testSuite.Errors
-		testsRun := max(testSuite.Tests-headerSkipped, 0)
-		passed := max(testsRun-totalFailures, 0)
-
-		// Convert time from seconds to duration string format (rounded to whole seconds)
-		var durationStr string
-		if testSuite.Time > 0 {
-			// Round to nearest second
-			roundedSeconds := int64(testSuite.Time + 0.5)
-			duration := time.Duration(roundedSeconds) * time.Second
-			durationStr = duration.String()
-		}
+		testsRun, passed, totalFailures, displaySkipped := countTests(testSuite)
 
 		sigRes := Sig{
 			Run:      testsRun,
 			Passed:   passed,
 			Failures: totalFailures,
 			Skipped:  displaySkipped,
-			Duration: durationStr,
+			Duration: formatDuration(testSuite.Time),
+			Metadata: newSuiteMetadata(testSuite),
+			Status:   testSuite.Status,
+		}
+
+		if testSuite.Incomplete {
+			sigRes.Incomplete = true
+			sigRes.Expected = testSuite.Expected
+			res.Summary.IncompleteSuites = append(res.Summary.IncompleteSuites, sig)
 		}
 
+		for _, subSuite := range testSuite.SubSuites {
+			subRun, subPassed, subFailures, subSkipped := countTests(subSuite)
+			sigRes.SubSuites = append(sigRes.SubSuites, SubSuite{
+				Name:     subSuite.Name,
+				Run:      subRun,
+				Passed:   subPassed,
+				Failures: subFailures,
+				Skipped:  subSkipped,
+				Duration: formatDuration(subSuite.Time),
+			})
+		}
+
+		for _, testCase := range testSuite.TestCases {
+			if testCase.IsFlaky() {
+				sigRes.FlakyTests = append(sigRes.FlakyTests, testCase.Name)
+			}
+		}
+		sigRes.Flaky = len(sigRes.FlakyTests)
+		sigRes.SkippedByReason = skippedByReason(testSuite.TestCases)
+
 		if totalFailures > 0 {
 			failedTests := make(map[string][]string)
+			failureReasons := make(map[string]string)
 			for _, testCase := range testSuite.TestCases {
-				if testCase.Failure || testCase.Error {
-					category := extractCategory(testCase.Classname)
+				if testCase.IsFailed() {
+					category := testCategory(testCase)
 					failedTests[category] = append(failedTests[category], testCase.Name)
+					if reason := trimReason(testCase.FailureReason()); reason != "" {
+						failureReasons[testCase.Name] = reason
+					}
 				}
 			}
 
 			sigRes.FailedTests = failedTests
+			if len(failureReasons) > 0 {
+				sigRes.FailureReasons = failureReasons
+			}
+			sigRes.FailedTestIDs = failedTestIDs(testSuite.TestCases)
 		}
 
+		sigRes.ByCriticality = groupCounts(testSuite.TestCases, critOf)
+		sigRes.ByLevel = groupCounts(testSuite.TestCases, levelOf)
+
+		durations := testDurations(sig, testSuite.TestCases)
+		allDurations = append(allDurations, durations...)
+		for _, test := range slowestTests(durations, slowestTestsLimit) {
+			test.Suite = ""
+			sigRes.SlowestTests = append(sigRes.SlowestTests, test)
+		}
+		sigRes.CategoryDurations = categoryDurations(testSuite.TestCases)
+
 		res.SigMap[sig] = sigRes
 		res.Summary.Run += testsRun
 		res.Summary.Passed += passed
 		res.Summary.Failed += totalFailures
 		res.Summary.Skipped += displaySkipped
+		res.Summary.Flaky += sigRes.Flaky
+		res.Summary.ByCriticality = mergeGroups(res.Summary.ByCriticality, sigRes.ByCriticality)
+		res.Summary.ByLevel = mergeGroups(res.Summary.ByLevel, sigRes.ByLevel)
 	}
 
+	res.Summary.SlowestTests = slowestTests(allDurations, slowestTestsLimit)
+	res.Summary.FailureSignatures = failureSignatures(junitResults)
+	sort.Strings(res.Summary.IncompleteSuites)
+
 	return res
 }
 
+// countTests derives the run, passed, failed and skipped counts of a JUnit test suite.
+func countTests(testSuite junit.TestSuite) (testsRun, passed, totalFailures, displaySkipped int) {
+	// headerSkipped is the count from the testsuite XML attributes.
+	// Only this value should be used to adjust testsRun, because the
+	// header "tests" attribute includes skipped tests in pytest/SSP
+	// but excludes them in Ginkgo.
+	headerSkipped := testSuite.Skipped + testSuite.Disabled
+
+	// displaySkipped is what we show in the summary. When the header
+	// doesn't report skipped counts (Ginkgo), fall back to counting
+	// individual testcase elements so the report still shows the total.
+	displaySkipped = headerSkipped
+	if displaySkipped == 0 {
+		for _, testCase := range testSuite.TestCases {
+			if testCase.IsSkipped() {
+				displaySkipped++
+			}
+		}
+	}
+
+	totalFailures = testSuite.Failures + testSuite.Errors
+	testsRun = max(testSuite.Tests-headerSkipped, 0)
+	passed = max(testsRun-totalFailures, 0)
+
+	return testsRun, passed, totalFailures, displaySkipped
+}
+
+// formatDuration converts time from seconds to duration string format (rounded to whole seconds).
+// Returns an empty string when the time is unknown.
+func formatDuration(seconds float64) string {
+	if seconds <= 0 {
+		return ""
+	}
+	// Round to nearest second
+	roundedSeconds := int64(seconds + 0.5)
+	return (time.Duration(roundedSeconds) * time.Second).String()
+}
+
 // SigMap is a map of test suite names to their corresponding Sig results.
 type SigMap map[string]Sig
 
@@ -105,6 +186,99 @@ type Sig struct {
 	Skipped     int            `json:"tests_skipped"`
 	Duration    string         `json:"tests_duration,omitempty"`
 	FailedTests FailedTestsMap `json:"failed_tests,omitempty"`
+	// Flaky counts the tests that passed only after being retried. Flaky
+	// tests are included in the Passed count.
+	Flaky      int      `json:"tests_flaky,omitempty"`
+	FlakyTests []string `json:"flaky_tests,omitempty"`
+	// SubSuites breaks the counts down per <testsuite> element, when the
+	// suite's JUnit file contained more than one of them.
+	SubSuites []SubSuite `json:"sub_suites,omitempty"`
+	// FailureReasons maps a failed test name to a trimmed, single-line
+	// failure message taken from the JUnit <failure> or <error> element.
+	FailureReasons map[string]string `json:"failure_reasons,omitempty"`
+	// SlowestTests lists the suite's longest running tests, slowest first.
+	SlowestTests []TestDuration `json:"slowest_tests,omitempty"`
+	// CategoryDurations holds the total test time per failed-tests category.
+	CategoryDurations map[string]string `json:"category_durations,omitempty"`
+	// FailedTestIDs maps the test_id tag of each failed test to its name.
+	FailedTestIDs map[string]string `json:"failed_test_ids,omitempty"`
+	// ByCriticality and ByLevel group the tests by their crit and level
+	// tags. Tests without the tag are not counted.
+	ByCriticality map[string]TestCounts `json:"by_criticality,omitempty"`
+	ByLevel       map[string]TestCounts `json:"by_level,omitempty"`
+	// Metadata holds the label filter, seed and framework version the suite
+	// was run with, taken from its JUnit properties.
+	Metadata *SuiteMetadata `json:"metadata,omitempty"`
+	// Incomplete is set when the suite's result files were missing or
+	// truncated and only the tests that completed before the run stopped
+	// were recovered from its log. Expected is the number of tests the run
+	// was going to execute, when known.
+	Incomplete bool `json:"incomplete,omitempty"`
+	Expected   int  `json:"tests_expected,omitempty"`
+	// Status is the outcome of the suite run as a whole, e.g. whether it
+	// completed, timed out or was interrupted.
+	Status junit.Status `json:"status,omitempty"`
+	// SkippedByReason counts the skipped tests per skip reason, e.g. the
+	// message of a runtime Skip() or "not selected by the run filters".
+	SkippedByReason map[string]int `json:"skipped_by_reason,omitempty"`
+	// KnownFailures lists the failed tests that match a known issue, and
+	// NewFailures the ones that do not. Both are only set when the result
+	// was checked against a known-issues catalog.
+	KnownFailures []KnownFailure `json:"known_failures,omitempty"`
+	NewFailures   []string       `json:"new_failures,omitempty"`
+	// Readiness is the suite's score weighted by the criticality and level
+	// of its tests. It is only set once the result was scored.
+	Readiness *Readiness `json:"readiness,omitempty"`
+	// Phases is the timeline of the suite run, in the order the phases
+	// started. WallClock is the time from the start of its first phase to
+	// the end of its last one, and TestTime the time spent in its tests.
+	Phases    []Phase `json:"phases,omitempty"`
+	WallClock string  `json:"wall_clock_duration,omitempty"`
+	TestTime  string  `json:"test_time,omitempty"`
+	// Omitted counts the entries left out of the lists of the suite, per
+	// list, when the result was truncated to fit in its ConfigMap.
+	Omitted map[string]int `json:"omitted,omitempty"`
+}
+
+// SubSuite represents the result of a single <testsuite> element within a suite's JUnit file.
+type SubSuite struct {
+	Name     string `json:"name"`
+	Run      int    `json:"tests_run"`
+	Passed   int    `json:"tests_passed"`
+	Failures int    `json:"tests_failures"`
+	Skipped  int    `json:"tests_skipped"`
+	Duration string `json:"tests_duration,omitempty"`
+}
+
+// maxReasonLength is the maximum number of characters kept from a failure message.
+const maxReasonLength = 300
+
+// trimReason collapses all whitespace in a failure message into single spaces
+// and truncates it to maxReasonLength characters, so that multi-line stack
+// traces do not blow up the ConfigMap.
+func trimReason(reason string) string {
+	reason = strings.Join(strings.Fields(reason), " ")
+	runes := []rune(reason)
+	if len(runes) <= maxReasonLength {
+		return reason
+	}
+	return string(runes[:maxReasonLength]) + "..."
+}
+
+// skippedByReason counts the skipped test cases per trimmed skip reason.
+// Returns nil when no test case was skipped.
+func skippedByReason(testCases []junit.TestCase) map[string]int {
+	var reasons map[string]int
+	for _, testCase := range testCases {
+		if !testCase.IsSkipped() {
+			continue
+		}
+		if reasons == nil {
+			reasons = make(map[string]int)
+		}
+		reasons[trimReason(testCase.SkipReason())]++
+	}
+	return reasons
 }
 
 // FailedTestsMap holds failed test names grouped by category.
@@ -145,14 +319,69 @@ func (f FailedTestsMap) isFlat() bool {
 }
 
 type Summary struct {
-	Run     int `json:"total_tests_run"`
-	Passed  int `json:"total_tests_passed"`
-	Failed  int `json:"total_tests_failed"`
-	Skipped int `json:"total_tests_skipped"`
+	Run          int            `json:"total_tests_run"`
+	Passed       int            `json:"total_tests_passed"`
+	Failed       int            `json:"total_tests_failed"`
+	Skipped      int            `json:"total_tests_skipped"`
+	Flaky        int            `json:"total_tests_flaky,omitempty"`
+	SlowestTests []TestDuration `json:"slowest_tests,omitempty"`
+	// KnownFailures and NewFailures count the failed tests that match a
+	// known issue and the ones that do not.
+	KnownFailures int `json:"total_known_failures,omitempty"`
+	NewFailures   int `json:"total_new_failures,omitempty"`
+	// Readiness is the score of all suites weighted by the criticality and
+	// level of their tests.
+	Readiness *Readiness `json:"readiness,omitempty"`
+	// Phases holds the phases of the run that belong to no suite, e.g. the
+	// Windows image setup. WallClock and TestTime cover all phases and tests
+	// of the run.
+	Phases    []Phase `json:"phases,omitempty"`
+	WallClock string  `json:"wall_clock_duration,omitempty"`
+	TestTime  string  `json:"test_time,omitempty"`
+	// FailureSignatures groups the failed tests of all suites by their
+	// normalized failure message, most common first, to point at the root
+	// causes shared by several failures.
+	FailureSignatures []FailureSignature `json:"failure_signatures,omitempty"`
+	// ByCriticality and ByLevel group the tests of all suites by their crit
+	// and level tags.
+	ByCriticality map[string]TestCounts `json:"by_criticality,omitempty"`
+	ByLevel       map[string]TestCounts `json:"by_level,omitempty"`
+	// Filter describes the crit and level filter the counts were restricted
+	// to, if any. It is only set on the copy of the result the text summary
+	// is rendered from, so it is not part of the published results.
+	Filter string `json:"-"`
+	// IncompleteSuites lists the suites whose results were only partially
+	// recovered.
+	IncompleteSuites []string `json:"incomplete_suites,omitempty"`
+	// SuiteStatuses holds the status of every suite, including the ones
+	// left out of the results because they did not run any test.
+	SuiteStatuses map[string]junit.Status `json:"suite_statuses,omitempty"`
+	// Verdict is the overall outcome of the run as judged by the policy it
+	// was evaluated with, and VerdictReasons lists the requirements it broke
+	// or could not check. Both are empty when no policy was given.
+	Verdict        Verdict  `json:"verdict,omitempty"`
+	VerdictReasons []string `json:"verdict_reasons,omitempty"`
+}
+
+// MarkNotStarted records the suites that were expected to run but left no
+// results behind as not started.
+func (r *Result) MarkNotStarted(expectedSuites []string) {
+	for _, sig := range expectedSuites {
+		if _, ok := r.SigMap[sig]; ok {
+			continue
+		}
+		if _, ok := r.Summary.SuiteStatuses[sig]; ok {
+			continue
+		}
+		if r.Summary.SuiteStatuses == nil {
+			r.Summary.SuiteStatuses = make(map[string]junit.Status)
+		}
+		r.Summary.SuiteStatuses[sig] = junit.StatusNotStarted
+	}
 }
 
 // MarshalJSON implements the json.Marshaler interface for Result, to make the SigMap's Sigs field inline in the
-// JSON output.
+// JSON output. The apiVersion comes first, so that readers can tell the format before the suites.
 func (r Result) MarshalJSON() ([]byte, error) {
 	type Alias Result
 	alias := Alias(r)
@@ -163,6 +392,12 @@ func (r Result) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 
+	apiVersionJSON, err := json.Marshal(r.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	alias.APIVersion = ""
 	alias.SigMap = nil
 	// Marshal the rest of the Result struct
 	aliasJSON, err := json.Marshal(alias)
@@ -170,96 +405,74 @@ func (r Result) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 
-	// Combine the two JSON objects
-	if len(r.SigMap) == 0 {
-		return aliasJSON, nil
+	// Combine the JSON objects, without their braces
+	var fields [][]byte
+	if r.APIVersion != "" {
+		fields = append(fields, append([]byte(`"apiVersion":`), apiVersionJSON...))
+	}
+	for _, object := range [][]byte{sigMapJSON, aliasJSON} {
+		if len(object) > 2 {
+			fields = append(fields, object[1:len(object)-1])
+		}
 	}
 
-	var resultJSON []byte
-	resultJSON = append(sigMapJSON[:len(sigMapJSON)-1], ',')
-	resultJSON = append(resultJSON, aliasJSON[1:]...) // Remove the opening brace from aliasJSON
+	resultJSON := []byte{'{'}
+	resultJSON = append(resultJSON, bytes.Join(fields, []byte{','})...)
+	resultJSON = append(resultJSON, '}')
 
 	return resultJSON, nil
 }
 
 // String implements the Stringer interface for Result, to provide a human-readable summary of the test results.
+// The layout is the one of the built-in summary template.
 func (r Result) String() string {
-	sb := strings.Builder{}
-
-	if r.SetupFailure && len(r.SigMap) == 0 {
-		sb.WriteString("ERROR: No tests were executed. One or more test suites failed during setup.\n")
-		sb.WriteString("Check the test logs for details.\n")
-		return sb.String()
-	}
-
-	for sig, sigRes := range r.SigMap {
-		// Print summary for suite
-		header := "Summary for " + sig
-		seperator := strings.Repeat("=", len(header))
-
-		sb.WriteString(seperator + "\n")
-		sb.WriteString(header + "\n")
-		sb.WriteString(seperator + "\n")
-
-		sb.WriteString(fmt.Sprintf("Tests Run: %d\n", sigRes.Run))
-		sb.WriteString(fmt.Sprintf("Tests Passed: %d\n", sigRes.Passed))
-		sb.WriteString(fmt.Sprintf("Tests Failed: %d\n", sigRes.Failures))
-		sb.WriteString(fmt.Sprintf("Tests Skipped: %d\n", sigRes.Skipped))
-		if sigRes.Duration != "" {
-			sb.WriteString(fmt.Sprintf("Tests Duration: %s\n", sigRes.Duration))
-		}
-
-		if len(sigRes.FailedTests) > 0 {
-			sb.WriteString("Failed Tests:\n")
-			writeFailedTests(&sb, sigRes.FailedTests)
-		}
+	summary, err := r.RenderTemplate(summaryTemplate)
+	if err != nil {
+		return err.Error() + "\n"
 	}
+	return string(summary)
+}
 
-	if r.SetupFailure {
-		sb.WriteString("\nWARNING: Some test suites failed during setup and were not included in the summary above.\n")
+// incompleteWarning describes how far an incomplete suite got, e.g.
+// "WARNING: Incomplete results recovered from the suite log: 37 of 120 tests completed".
+func incompleteWarning(sigRes Sig) string {
+	completed := sigRes.Run + sigRes.Skipped
+	if sigRes.Expected > 0 {
+		return fmt.Sprintf("WARNING: Incomplete results recovered from the suite log: %d of %d tests completed",
+			completed, sigRes.Expected)
 	}
-
-	header := fmt.Sprintf("Total Summary for execution from %s", os.Getenv("TIMESTAMP"))
-	seperator := strings.Repeat("=", len(header))
-	sb.WriteString(seperator + "\n")
-	sb.WriteString(header + "\n")
-	sb.WriteString(seperator + "\n")
-	sb.WriteString(fmt.Sprintf("Total Tests Run: %d\n", r.Summary.Run))
-	sb.WriteString(fmt.Sprintf("Total Tests Passed: %d\n", r.Summary.Passed))
-	sb.WriteString(fmt.Sprintf("Total Tests Failed: %d\n", r.Summary.Failed))
-	sb.WriteString(fmt.Sprintf("Total Tests Skipped: %d\n", r.Summary.Skipped))
-
-	return sb.String()
+	return fmt.Sprintf("WARNING: Incomplete results recovered from the suite log: %d tests completed", completed)
 }
 
-// writeFailedTests writes the failed tests map to the string builder.
-// When all tests share a single uncategorized bucket (empty key), a flat list is produced.
-// Otherwise, tests are grouped under sorted category headings.
-func writeFailedTests(sb *strings.Builder, failedTests map[string][]string) {
-	_, hasUncategorized := failedTests[""]
-	if len(failedTests) == 1 && hasUncategorized {
-		for _, testName := range failedTests[""] {
-			sb.WriteString(fmt.Sprintf("  - %s\n", testName))
-		}
-		return
+// tagPattern matches the tags and decorators KubeVirt embeds in container
+// texts, e.g. "[sig-compute]" or "[Serial]".
+var tagPattern = regexp.MustCompile(`\[[^\]]*\]`)
+
+// testCategory derives the category of a test case: from its classname for
+// pytest tests, and from its sig tag and top-level Describe container for
+// Ginkgo tests, e.g. "sig-compute/VM Live Migration". The container is only
+// known for suites read from a Ginkgo JSON report, so tests read from JUnit
+// files or logs are only categorized by their sig tag.
+func testCategory(testCase junit.TestCase) string {
+	if category := extractCategory(testCase.Classname); category != "" {
+		return category
 	}
 
-	categories := make([]string, 0, len(failedTests))
-	for cat := range failedTests {
-		categories = append(categories, cat)
+	container := strings.TrimSpace(tagPattern.ReplaceAllString(testCase.Container, " "))
+	container = strings.Join(strings.Fields(container), " ")
+
+	var sig string
+	if len(testCase.Metadata.Sigs) > 0 {
+		sig = testCase.Metadata.Sigs[0]
 	}
-	sort.Strings(categories)
 
-	for _, cat := range categories {
-		label := cat
-		if label == "" {
-			label = "uncategorized"
-		}
-		sb.WriteString(fmt.Sprintf("  %s:\n", label))
-		for _, testName := range failedTests[cat] {
-			sb.WriteString(fmt.Sprintf("    - %s\n", testName))
-		}
+	switch {
+	case sig != "" && container != "":
+		return sig + "/" + container
+	case sig != "":
+		return sig
 	}
+	return container
 }
 
 // extractCategory derives a SIG category from a pytest classname.
@@ -291,6 +504,55 @@ func extractCategory(classname string) string {
 	return category
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface for Result, reading
+// every key but "apiVersion" and "summary" as a suite, the reverse of
+// MarshalJSON.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	r.SigMap = make(SigMap)
+	for key, raw := range fields {
+		switch key {
+		case "apiVersion":
+			if err := json.Unmarshal(raw, &r.APIVersion); err != nil {
+				return fmt.Errorf("failed to decode apiVersion: %w", err)
+			}
+			continue
+		case "summary":
+			if err := json.Unmarshal(raw, &r.Summary); err != nil {
+				return fmt.Errorf("failed to decode summary: %w", err)
+			}
+			continue
+		}
+
+		var sigRes Sig
+		if err := json.Unmarshal(raw, &sigRes); err != nil {
+			return fmt.Errorf("failed to decode suite %q: %w", key, err)
+		}
+		r.SigMap[key] = sigRes
+	}
+
+	return nil
+}
+
+// Parse decodes a result from JSON, as written with --output=json, or from
+// YAML, as stored in the ConfigMap.
+func Parse(data []byte) (Result, error) {
+	resJson, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to decode result: %w", err)
+	}
+
+	var res Result
+	if err := json.Unmarshal(resJson, &res); err != nil {
+		return Result{}, fmt.Errorf("failed to decode result: %w", err)
+	}
+	return res, nil
+}
+
 // GetYaml converts the Result struct to YAML format.
 func (r Result) GetYaml() ([]byte, error) {
 	resJson, err := json.Marshal(r)
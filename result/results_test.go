@@ -2,6 +2,7 @@ package result_test
 
 import (
 	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -17,7 +18,7 @@ func TestCreatesResultWithValidJUnitResults(t *testing.T) {
 			Skipped:  1,
 			Disabled: 0,
 			TestCases: []junit.TestCase{
-				{Name: "test1", Classname: "Tests Suite", Failure: true},
+				{Name: "test1", Classname: "Tests Suite", Failure: &junit.Outcome{}},
 				{Name: "test2", Classname: "Tests Suite"},
 			},
 		},
@@ -140,11 +141,11 @@ func TestGinkgoSkippedOnlyInTestcasesDoesNotAffectRunCount(t *testing.T) {
 			Skipped:  0,
 			Disabled: 0,
 			TestCases: []junit.TestCase{
-				{Name: "test1", Failure: true},
+				{Name: "test1", Failure: &junit.Outcome{}},
 				{Name: "test2"},
-				{Name: "skipped1", Skipped: true},
-				{Name: "skipped2", Skipped: true},
-				{Name: "skipped3", Skipped: true},
+				{Name: "skipped1", Skipped: &junit.Outcome{}},
+				{Name: "skipped2", Skipped: &junit.Outcome{}},
+				{Name: "skipped3", Skipped: &junit.Outcome{}},
 			},
 		},
 	}
@@ -171,7 +172,7 @@ func TestMarshalsResultToJSONCorrectly(t *testing.T) {
 			Skipped:  0,
 			Disabled: 0,
 			TestCases: []junit.TestCase{
-				{Name: "test1", Classname: "Tests Suite", Failure: true},
+				{Name: "test1", Classname: "Tests Suite", Failure: &junit.Outcome{}},
 			},
 		},
 	}
@@ -182,7 +183,7 @@ func TestMarshalsResultToJSONCorrectly(t *testing.T) {
 		t.Fatalf("unexpected error marshaling result to JSON: %v", err)
 	}
 
-	expected := `{"sig1":{"tests_run":2,"tests_passed":1,"tests_failures":1,"tests_skipped":0,"failed_tests":["test1"]},"summary":{"total_tests_run":2,"total_tests_passed":1,"total_tests_failed":1,"total_tests_skipped":0}}`
+	expected := `{"apiVersion":"ocp-virt-validation/v1","sig1":{"tests_run":2,"tests_passed":1,"tests_failures":1,"tests_skipped":0,"failed_tests":["test1"]},"summary":{"total_tests_run":2,"total_tests_passed":1,"total_tests_failed":1,"total_tests_skipped":0}}`
 	if string(jsonData) != expected {
 		t.Errorf("expected JSON %s, got %s", expected, string(jsonData))
 	}
@@ -222,7 +223,7 @@ func TestSetupFailureWithOtherValidSuites(t *testing.T) {
 			Tests:    10,
 			Failures: 1,
 			TestCases: []junit.TestCase{
-				{Name: "test1", Failure: true},
+				{Name: "test1", Failure: &junit.Outcome{}},
 			},
 		},
 	}
@@ -251,9 +252,9 @@ func TestCategorizesFailedTestsByClassname(t *testing.T) {
 			Skipped:  0,
 			Disabled: 0,
 			TestCases: []junit.TestCase{
-				{Name: "test_hotplug_volume", Classname: "tests.storage.test_hotplug.TestHotPlug", Failure: true},
-				{Name: "test_smbios_default", Classname: "tests.virt.cluster.general.test_smbios", Failure: true},
-				{Name: "test_namespace_health", Classname: "tests.after_cluster_deploy_sanity.test_sanity", Failure: true},
+				{Name: "test_hotplug_volume", Classname: "tests.storage.test_hotplug.TestHotPlug", Failure: &junit.Outcome{}},
+				{Name: "test_smbios_default", Classname: "tests.virt.cluster.general.test_smbios", Failure: &junit.Outcome{}},
+				{Name: "test_namespace_health", Classname: "tests.after_cluster_deploy_sanity.test_sanity", Failure: &junit.Outcome{}},
 				{Name: "test_passing", Classname: "tests.virt.node.test_node"},
 				{Name: "test_also_passing", Classname: "tests.storage.test_resize"},
 			},
@@ -287,6 +288,43 @@ func TestCategorizesFailedTestsByClassname(t *testing.T) {
 	}
 }
 
+func TestCategorizesGinkgoFailedTestsBySigAndContainer(t *testing.T) {
+	migration := "[rfe_id:393][crit:high][level:system][sig-compute] VM Live Migration"
+	junitResults := map[string]junit.TestSuite{
+		"compute": {
+			Tests:    4,
+			Failures: 4,
+			TestCases: []junit.TestCase{
+				{Name: migration + " Starting a VirtualMachineInstance should migrate", Classname: migration + " Starting a VirtualMachineInstance",
+					Container: migration, Metadata: junit.ParseMetadata(migration, nil), Failure: &junit.Outcome{}},
+				{Name: "[sig-compute]Hotplug [Serial] should plug vCPUs", Classname: "[sig-compute]Hotplug [Serial]",
+					Container: "[sig-compute]Hotplug [Serial]", Metadata: junit.ParseMetadata("[sig-compute]Hotplug", nil), Failure: &junit.Outcome{}},
+				{Name: "[sig-network] should be reachable", Classname: "Tests Suite",
+					Metadata: junit.ParseMetadata("[sig-network] should be reachable", nil), Failure: &junit.Outcome{}},
+				{Name: "[BeforeSuite]", Classname: "Tests Suite", Failure: &junit.Outcome{}},
+			},
+		},
+	}
+
+	res := result.New(junitResults)
+
+	expected := result.FailedTestsMap{
+		"sig-compute/VM Live Migration": {migration + " Starting a VirtualMachineInstance should migrate"},
+		"sig-compute/Hotplug":           {"[sig-compute]Hotplug [Serial] should plug vCPUs"},
+		"sig-network":                   {"[sig-network] should be reachable"},
+		"":                              {"[BeforeSuite]"},
+	}
+	if !reflect.DeepEqual(res.SigMap["compute"].FailedTests, expected) {
+		t.Errorf("expected failed tests %v, got %v", expected, res.SigMap["compute"].FailedTests)
+	}
+
+	output := res.String()
+	if !strings.Contains(output, "  sig-compute/Hotplug:\n    - [sig-compute]Hotplug [Serial] should plug vCPUs\n") ||
+		!strings.Contains(output, "  uncategorized:\n    - [BeforeSuite]\n") {
+		t.Errorf("expected the Ginkgo failed tests to be grouped, got:\n%s", output)
+	}
+}
+
 func TestCategorizedStringOutputIsHierarchical(t *testing.T) {
 	junitResults := map[string]junit.TestSuite{
 		"tier2": {
@@ -295,9 +333,9 @@ func TestCategorizedStringOutputIsHierarchical(t *testing.T) {
 			Skipped:  0,
 			Disabled: 0,
 			TestCases: []junit.TestCase{
-				{Name: "test_hotplug", Classname: "tests.storage.test_hotplug.TestHotPlug", Failure: true},
-				{Name: "test_smbios", Classname: "tests.virt.cluster.general.test_smbios", Failure: true},
-				{Name: "test_cpu", Classname: "tests.virt.node.cpu_sockets_threads.test_cpu", Failure: true},
+				{Name: "test_hotplug", Classname: "tests.storage.test_hotplug.TestHotPlug", Failure: &junit.Outcome{}},
+				{Name: "test_smbios", Classname: "tests.virt.cluster.general.test_smbios", Failure: &junit.Outcome{}},
+				{Name: "test_cpu", Classname: "tests.virt.node.cpu_sockets_threads.test_cpu", Failure: &junit.Outcome{}},
 				{Name: "test_passing", Classname: "tests.virt.node.test_node"},
 			},
 		},
@@ -334,7 +372,7 @@ func TestUncategorizedStringOutputIsFlat(t *testing.T) {
 			Skipped:  0,
 			Disabled: 0,
 			TestCases: []junit.TestCase{
-				{Name: "test_migration", Classname: "Tests Suite", Failure: true},
+				{Name: "test_migration", Classname: "Tests Suite", Failure: &junit.Outcome{}},
 				{Name: "test_passing", Classname: "Tests Suite"},
 			},
 		},
@@ -359,8 +397,8 @@ func TestCategorizedJSONOutputUsesMap(t *testing.T) {
 			Skipped:  0,
 			Disabled: 0,
 			TestCases: []junit.TestCase{
-				{Name: "test_hotplug", Classname: "tests.storage.test_hotplug.TestHotPlug", Failure: true},
-				{Name: "test_smbios", Classname: "tests.virt.cluster.general.test_smbios", Failure: true},
+				{Name: "test_hotplug", Classname: "tests.storage.test_hotplug.TestHotPlug", Failure: &junit.Outcome{}},
+				{Name: "test_smbios", Classname: "tests.virt.cluster.general.test_smbios", Failure: &junit.Outcome{}},
 				{Name: "test_passing", Classname: "tests.virt.node.test_node"},
 			},
 		},
@@ -389,7 +427,7 @@ func TestConvertsResultToYAMLCorrectly(t *testing.T) {
 			Skipped:  0,
 			Disabled: 0,
 			TestCases: []junit.TestCase{
-				{Name: "test1", Classname: "Tests Suite", Failure: true},
+				{Name: "test1", Classname: "Tests Suite", Failure: &junit.Outcome{}},
 			},
 		},
 	}
@@ -400,7 +438,8 @@ func TestConvertsResultToYAMLCorrectly(t *testing.T) {
 		t.Fatalf("unexpected error converting result to YAML: %v", err)
 	}
 
-	expected := `sig1:
+	expected := `apiVersion: ocp-virt-validation/v1
+sig1:
   failed_tests:
   - test1
   tests_failures: 1
@@ -418,3 +457,449 @@ summary:
 		t.Errorf("expected YAML:\n%s\n\ngot:\n%s", expected, string(yamlData))
 	}
 }
+
+func TestCarriesTrimmedFailureReasons(t *testing.T) {
+	longReason := strings.Repeat("x", 400)
+	junitResults := map[string]junit.TestSuite{
+		"compute": {
+			Tests:    3,
+			Failures: 2,
+			TestCases: []junit.TestCase{
+				{Name: "test_migration", Classname: "Tests Suite", Failure: &junit.Outcome{
+					Type: "Failure",
+					Text: "tests/migration/migration.go:1168\nTimed out after 180.001s.\n  VMI did not start",
+				}},
+				{Name: "test_long", Classname: "Tests Suite", Error: &junit.Outcome{Message: longReason}},
+				{Name: "test_passing", Classname: "Tests Suite"},
+			},
+		},
+	}
+
+	res := result.New(junitResults)
+
+	sig := res.SigMap["compute"]
+	expected := "tests/migration/migration.go:1168 Timed out after 180.001s. VMI did not start"
+	if sig.FailureReasons["test_migration"] != expected {
+		t.Errorf("expected reason %q, got %q", expected, sig.FailureReasons["test_migration"])
+	}
+	if got := sig.FailureReasons["test_long"]; got != strings.Repeat("x", 300)+"..." {
+		t.Errorf("expected reason to be truncated to 300 characters, got %d characters", len(got))
+	}
+	if _, ok := sig.FailureReasons["test_passing"]; ok {
+		t.Error("expected no reason for a passing test")
+	}
+
+	output := res.String()
+	if !strings.Contains(output, "  - test_migration\n    Reason: "+expected+"\n") {
+		t.Errorf("expected output to contain the failure reason below the test name, got:\n%s", output)
+	}
+}
+
+func TestFailureReasonsInYAML(t *testing.T) {
+	junitResults := map[string]junit.TestSuite{
+		"sig1": {
+			Tests:    1,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "test1", Classname: "Tests Suite", Failure: &junit.Outcome{Message: "PVC not bound"}},
+			},
+		},
+	}
+
+	res := result.New(junitResults)
+	yamlData, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting result to YAML: %v", err)
+	}
+
+	if !strings.Contains(string(yamlData), "  failure_reasons:\n    test1: PVC not bound\n") {
+		t.Errorf("expected YAML to contain failure_reasons, got:\n%s", string(yamlData))
+	}
+}
+
+func TestReportsSubSuiteBreakdown(t *testing.T) {
+	nodeOne := junit.TestSuite{
+		Name:     "node 1",
+		Tests:    3,
+		Failures: 1,
+		Time:     100.4,
+		TestCases: []junit.TestCase{
+			{Name: "test1", Failure: &junit.Outcome{}},
+			{Name: "test2"},
+			{Name: "test3"},
+		},
+	}
+	nodeTwo := junit.TestSuite{
+		Name:  "node 2",
+		Tests: 1,
+		TestCases: []junit.TestCase{
+			{Name: "test4"},
+			{Name: "test5", Skipped: &junit.Outcome{}},
+		},
+	}
+
+	junitResults := map[string]junit.TestSuite{
+		"compute": {
+			Tests:     4,
+			Failures:  1,
+			Time:      100.4,
+			TestCases: append(append([]junit.TestCase{}, nodeOne.TestCases...), nodeTwo.TestCases...),
+			SubSuites: []junit.TestSuite{nodeOne, nodeTwo},
+		},
+	}
+
+	res := result.New(junitResults)
+
+	sig := res.SigMap["compute"]
+	if sig.Run != 4 || sig.Passed != 3 || sig.Skipped != 1 {
+		t.Errorf("unexpected aggregated counts: %+v", sig)
+	}
+
+	expected := []result.SubSuite{
+		{Name: "node 1", Run: 3, Passed: 2, Failures: 1, Skipped: 0, Duration: "1m40s"},
+		{Name: "node 2", Run: 1, Passed: 1, Failures: 0, Skipped: 1},
+	}
+	if len(sig.SubSuites) != len(expected) {
+		t.Fatalf("expected %d sub-suites, got %d", len(expected), len(sig.SubSuites))
+	}
+	for i := range expected {
+		if sig.SubSuites[i] != expected[i] {
+			t.Errorf("expected sub-suite %+v, got %+v", expected[i], sig.SubSuites[i])
+		}
+	}
+
+	output := res.String()
+	if !strings.Contains(output, "Sub-suites:\n  - node 1: run 3, passed 2, failed 1, skipped 0\n") {
+		t.Errorf("expected output to contain the sub-suite breakdown, got:\n%s", output)
+	}
+}
+
+func TestReportsFlakyTests(t *testing.T) {
+	junitResults := map[string]junit.TestSuite{
+		"compute": {
+			Tests:    3,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				{Name: "test_flaky", Attempts: 2},
+				{Name: "test_retried_and_failed", Attempts: 3, Failure: &junit.Outcome{}},
+				{Name: "test_stable", Attempts: 1},
+			},
+		},
+		"network": {
+			Tests: 1,
+			TestCases: []junit.TestCase{
+				{Name: "test_network_flaky", Attempts: 3},
+			},
+		},
+	}
+
+	res := result.New(junitResults)
+
+	compute := res.SigMap["compute"]
+	if compute.Flaky != 1 || len(compute.FlakyTests) != 1 || compute.FlakyTests[0] != "test_flaky" {
+		t.Errorf("expected only test_flaky to be flaky, got %d %v", compute.Flaky, compute.FlakyTests)
+	}
+	if compute.Passed != 2 {
+		t.Errorf("expected flaky tests to be counted as passed, got %d passed", compute.Passed)
+	}
+	if res.Summary.Flaky != 2 {
+		t.Errorf("expected 2 flaky tests in summary, got %d", res.Summary.Flaky)
+	}
+
+	output := res.String()
+	if !strings.Contains(output, "Tests Flaky: 1\n") || !strings.Contains(output, "Flaky Tests (passed on retry):\n  - test_flaky\n") {
+		t.Errorf("expected flaky tests in output, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Total Tests Flaky: 2\n") {
+		t.Errorf("expected flaky total in output, got:\n%s", output)
+	}
+
+	yamlData, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting result to YAML: %v", err)
+	}
+	if !strings.Contains(string(yamlData), "  flaky_tests:\n  - test_flaky\n") || !strings.Contains(string(yamlData), "  tests_flaky: 1\n") {
+		t.Errorf("expected flaky tests in YAML, got:\n%s", string(yamlData))
+	}
+	if !strings.Contains(string(yamlData), "  total_tests_flaky: 2\n") {
+		t.Errorf("expected flaky total in YAML, got:\n%s", string(yamlData))
+	}
+}
+
+func TestGroupsTestsByCriticalityAndLevel(t *testing.T) {
+	testCase := func(name string, failed bool) junit.TestCase {
+		tc := junit.TestCase{Name: name, Classname: "Tests Suite", Metadata: junit.ParseMetadata(name, nil)}
+		if failed {
+			tc.Failure = &junit.Outcome{}
+		}
+		return tc
+	}
+
+	junitResults := map[string]junit.TestSuite{
+		"compute": {
+			Tests:    3,
+			Failures: 1,
+			TestCases: []junit.TestCase{
+				testCase("[crit:high][level:system] VM [test_id:1783]should migrate", false),
+				testCase("[crit:medium][level:system] VM [test_id:1621]should start", true),
+				testCase("untagged", false),
+			},
+		},
+		"network": {
+			Tests: 1,
+			TestCases: []junit.TestCase{
+				testCase("[crit:high][level:component] SR-IOV [test_id:3957]should connect", false),
+			},
+		},
+	}
+
+	res := result.New(junitResults)
+
+	compute := res.SigMap["compute"]
+	if ids := compute.FailedTestIDs; len(ids) != 1 || ids["1621"] != "[crit:medium][level:system] VM [test_id:1621]should start" {
+		t.Errorf("unexpected failed test IDs: %v", ids)
+	}
+	if counts := compute.ByCriticality["medium"]; counts.Run != 1 || counts.Failures != 1 {
+		t.Errorf("unexpected crit:medium counts: %+v", counts)
+	}
+	if len(res.SigMap["network"].FailedTestIDs) != 0 {
+		t.Errorf("expected no failed test IDs for network, got %v", res.SigMap["network"].FailedTestIDs)
+	}
+
+	high := res.Summary.ByCriticality["high"]
+	if high.Run != 2 || high.Passed != 2 || !high.AllPassed() {
+		t.Errorf("expected all crit:high tests to pass, got %+v", high)
+	}
+	if system := res.Summary.ByLevel["system"]; system.Run != 2 || system.Failures != 1 || system.AllPassed() {
+		t.Errorf("unexpected level:system counts: %+v", system)
+	}
+
+	output := res.String()
+	expected := "Tests by Criticality:\n" +
+		"  - crit:high: run 2, passed 2, failed 0, skipped 0 (all passed)\n" +
+		"  - crit:medium: run 1, passed 0, failed 1, skipped 0\n" +
+		"Tests by Level:\n" +
+		"  - level:component: run 1, passed 1, failed 0, skipped 0 (all passed)\n" +
+		"  - level:system: run 2, passed 1, failed 1, skipped 0\n"
+	if !strings.Contains(output, expected) {
+		t.Errorf("expected output to contain:\n%s\ngot:\n%s", expected, output)
+	}
+
+	yamlData, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting result to YAML: %v", err)
+	}
+	if !strings.Contains(string(yamlData), "  failed_test_ids:\n    \"1621\": '[crit:medium][level:system] VM [test_id:1621]should start'\n") {
+		t.Errorf("expected YAML to contain failed_test_ids, got:\n%s", string(yamlData))
+	}
+}
+
+func TestReportsSuiteMetadataFromProperties(t *testing.T) {
+	junitResults := map[string]junit.TestSuite{
+		"compute": {
+			Tests:     1,
+			TestCases: []junit.TestCase{{Name: "test1", Classname: "Tests Suite"}},
+			Properties: []junit.Property{
+				{Name: "RandomSeed", Value: "1747735200"},
+				{Name: "LabelFilter", Value: "(sig-compute&&conformance)"},
+				{Name: "SkipStrings", Value: `(\[QUARANTINE\])`},
+				{Name: "FocusStrings", Value: ""},
+				{Name: "Ginkgo_Version", Value: "2.22.0"},
+				{Name: "FlakeAttempts", Value: "3"},
+			},
+		},
+		"tier2": {
+			Tests:     1,
+			TestCases: []junit.TestCase{{Name: "test_a", Classname: "tests.virt.test_a"}},
+		},
+	}
+
+	res := result.New(junitResults)
+
+	md := res.SigMap["compute"].Metadata
+	if md == nil {
+		t.Fatal("expected metadata for compute")
+	}
+	if md.LabelFilter != "(sig-compute&&conformance)" || md.RandomSeed != "1747735200" ||
+		md.Skip != `(\[QUARANTINE\])` || md.Focus != "" || md.FrameworkVersion != "2.22.0" {
+		t.Errorf("unexpected metadata: %+v", md)
+	}
+	if len(md.Properties) != 1 || md.Properties["FlakeAttempts"] != "3" {
+		t.Errorf("expected only FlakeAttempts in the remaining properties, got %v", md.Properties)
+	}
+	if res.SigMap["tier2"].Metadata != nil {
+		t.Errorf("expected no metadata for tier2, got %+v", res.SigMap["tier2"].Metadata)
+	}
+
+	expected := "Metadata:\n" +
+		"  Label Filter: (sig-compute&&conformance)\n" +
+		"  Skip: (\\[QUARANTINE\\])\n" +
+		"  Random Seed: 1747735200\n" +
+		"  Framework Version: 2.22.0\n"
+	if output := res.String(); !strings.Contains(output, expected) {
+		t.Errorf("expected output to contain:\n%s\ngot:\n%s", expected, output)
+	}
+
+	yamlData, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting result to YAML: %v", err)
+	}
+	if !strings.Contains(string(yamlData), "  metadata:\n    framework_version: 2.22.0\n    label_filter: (sig-compute&&conformance)\n") {
+		t.Errorf("expected YAML to contain the metadata section, got:\n%s", string(yamlData))
+	}
+}
+
+func TestReportsIncompleteSuites(t *testing.T) {
+	junitResults := map[string]junit.TestSuite{
+		"compute": {
+			Tests:      3,
+			Failures:   1,
+			Skipped:    1,
+			Incomplete: true,
+			Expected:   120,
+			TestCases: []junit.TestCase{
+				{Name: "test1", Classname: "Tests Suite", Failure: &junit.Outcome{}},
+				{Name: "test2", Classname: "Tests Suite"},
+				{Name: "test3", Classname: "Tests Suite", Skipped: &junit.Outcome{}},
+			},
+		},
+		"network": {
+			Tests:     1,
+			TestCases: []junit.TestCase{{Name: "test1", Classname: "Tests Suite"}},
+		},
+	}
+
+	res := result.New(junitResults)
+
+	compute := res.SigMap["compute"]
+	if !compute.Incomplete || compute.Expected != 120 || compute.Run != 2 || compute.Failures != 1 {
+		t.Errorf("unexpected compute result: %+v", compute)
+	}
+	if res.SigMap["network"].Incomplete {
+		t.Error("expected network to be complete")
+	}
+	if len(res.Summary.IncompleteSuites) != 1 || res.Summary.IncompleteSuites[0] != "compute" {
+		t.Errorf("expected compute to be listed as incomplete, got %v", res.Summary.IncompleteSuites)
+	}
+
+	output := res.String()
+	for _, expected := range []string{
+		"WARNING: Incomplete results recovered from the suite log: 3 of 120 tests completed\n",
+		"WARNING: The results of some test suites are incomplete: compute\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	yamlData, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting result to YAML: %v", err)
+	}
+	for _, expected := range []string{"  incomplete: true\n", "  tests_expected: 120\n", "  incomplete_suites:\n  - compute\n"} {
+		if !strings.Contains(string(yamlData), expected) {
+			t.Errorf("expected YAML to contain %q, got:\n%s", expected, string(yamlData))
+		}
+	}
+}
+
+func TestReportsSuiteStatuses(t *testing.T) {
+	junitResults := map[string]junit.TestSuite{
+		"compute": {
+			Tests:    2,
+			Failures: 1,
+			Status:   junit.StatusCompletedWithFailures,
+			TestCases: []junit.TestCase{
+				{Name: "test1", Classname: "Tests Suite", Failure: &junit.Outcome{}},
+				{Name: "test2", Classname: "Tests Suite"},
+			},
+		},
+		"ssp": {
+			SetupFailure: true,
+			Status:       junit.StatusSetupFailed,
+		},
+	}
+
+	res := result.New(junitResults)
+	res.MarkNotStarted([]string{"compute", "ssp", "tier2"})
+
+	if res.SigMap["compute"].Status != junit.StatusCompletedWithFailures {
+		t.Errorf("expected compute status %q, got %q", junit.StatusCompletedWithFailures, res.SigMap["compute"].Status)
+	}
+	if _, ok := res.SigMap["ssp"]; ok {
+		t.Error("expected ssp to be excluded from the suite results")
+	}
+
+	expected := map[string]junit.Status{
+		"compute": junit.StatusCompletedWithFailures,
+		"ssp":     junit.StatusSetupFailed,
+		"tier2":   junit.StatusNotStarted,
+	}
+	if len(res.Summary.SuiteStatuses) != len(expected) {
+		t.Errorf("expected %d suite statuses, got %v", len(expected), res.Summary.SuiteStatuses)
+	}
+	for sig, status := range expected {
+		if res.Summary.SuiteStatuses[sig] != status {
+			t.Errorf("expected %s status %q, got %q", sig, status, res.Summary.SuiteStatuses[sig])
+		}
+	}
+
+	output := res.String()
+	if !strings.Contains(output, "Status: completed-with-failures\n") {
+		t.Errorf("expected output to contain the compute status, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Suite Statuses:\n  - compute: completed-with-failures\n  - ssp: setup-failed\n  - tier2: not-started\n") {
+		t.Errorf("expected output to list the suite statuses, got:\n%s", output)
+	}
+
+	yamlData, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting result to YAML: %v", err)
+	}
+	if !strings.Contains(string(yamlData), "  suite_statuses:\n    compute: completed-with-failures\n    ssp: setup-failed\n    tier2: not-started\n") {
+		t.Errorf("expected YAML to contain the suite statuses, got:\n%s", string(yamlData))
+	}
+}
+
+func TestGroupsSkippedTestsByReason(t *testing.T) {
+	junitResults := map[string]junit.TestSuite{
+		"storage": {
+			Tests: 5,
+			TestCases: []junit.TestCase{
+				{Name: "test1", Classname: "Tests Suite"},
+				{Name: "test2", Classname: "Tests Suite", Skipped: &junit.Outcome{Message: "skipped - Skip: cluster does not have RWX block storage"}},
+				{Name: "test3", Classname: "Tests Suite", Skipped: &junit.Outcome{Message: "skipped - cluster does not have RWX block storage"}},
+				{Name: "test4", Classname: "Tests Suite", Skipped: &junit.Outcome{Message: "skipped - cluster does not have RWX block storage"}},
+				{Name: "test5", Classname: "Tests Suite", Skipped: &junit.Outcome{Message: "skipped"}},
+			},
+		},
+		"network": {
+			Tests:     1,
+			TestCases: []junit.TestCase{{Name: "test1", Classname: "Tests Suite"}},
+		},
+	}
+
+	res := result.New(junitResults)
+
+	storage := res.SigMap["storage"].SkippedByReason
+	if len(storage) != 2 || storage["cluster does not have RWX block storage"] != 3 || storage[junit.SkipReasonNotSelected] != 1 {
+		t.Errorf("unexpected skipped tests by reason: %v", storage)
+	}
+	if res.SigMap["network"].SkippedByReason != nil {
+		t.Errorf("expected no skip reasons for network, got %v", res.SigMap["network"].SkippedByReason)
+	}
+
+	output := res.String()
+	expected := "Skipped by Reason:\n  - 3: cluster does not have RWX block storage\n  - 1: not selected by the run filters\n"
+	if !strings.Contains(output, expected) {
+		t.Errorf("expected output to contain %q, got:\n%s", expected, output)
+	}
+
+	yamlData, err := res.GetYaml()
+	if err != nil {
+		t.Fatalf("unexpected error converting result to YAML: %v", err)
+	}
+	if !strings.Contains(string(yamlData), "  skipped_by_reason:\n    cluster does not have RWX block storage: 3\n    not selected by the run filters: 1\n") {
+		t.Errorf("expected YAML to contain the skip reasons, got:\n%s", string(yamlData))
+	}
+}
@@ -0,0 +1,16 @@
+package result
+
+import (
+	_ "embed"
+)
+
+// APIVersion is the version of the result format. It only changes when the
+// format changes in a way older consumers cannot read; optional fields are
+// added within a version.
+const APIVersion = "ocp-virt-validation/v1"
+
+// Schema is the JSON Schema of the result format, as stored in the results
+// ConfigMap and written with --output=json or --output=yaml.
+//
+//go:embed result.schema.json
+var Schema []byte
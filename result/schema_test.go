@@ -0,0 +1,65 @@
+package result_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"junitparser/result"
+)
+
+// jsonFields returns the sorted JSON names of the serialized fields of a struct.
+func jsonFields(t reflect.Type) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" || name == "" {
+			continue
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func TestSchemaDescribesEveryField(t *testing.T) {
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(result.Schema, &schema); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+
+	if !strings.Contains(string(schema.Properties["apiVersion"]), `"`+result.APIVersion+`"`) {
+		t.Errorf("expected the schema to allow apiVersion %s", result.APIVersion)
+	}
+
+	for def, value := range map[string]any{
+		"suite":            result.Sig{},
+		"summary":          result.Summary{},
+		"subSuite":         result.SubSuite{},
+		"testDuration":     result.TestDuration{},
+		"testCounts":       result.TestCounts{},
+		"suiteMetadata":    result.SuiteMetadata{},
+		"knownFailure":     result.KnownFailure{},
+		"failureSignature": result.FailureSignature{},
+		"readiness":        result.Readiness{},
+		"phase":            result.Phase{},
+	} {
+		var properties []string
+		for name := range schema.Defs[def].Properties {
+			properties = append(properties, name)
+		}
+		sort.Strings(properties)
+
+		if fields := jsonFields(reflect.TypeOf(value)); !reflect.DeepEqual(properties, fields) {
+			t.Errorf("schema %s describes %v, expected %v", def, properties, fields)
+		}
+	}
+}
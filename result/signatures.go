@@ -0,0 +1,122 @@
+package result
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"junitparser/junit_parser/junit"
+)
+
+const (
+	// failureSignaturesLimit is the number of failure signatures reported.
+	failureSignaturesLimit = 10
+	// signatureExamplesLimit is the number of example tests reported per
+	// failure signature.
+	signatureExamplesLimit = 3
+)
+
+// FailureSignature groups the failed tests whose failure messages are the
+// same once the run-specific parts, e.g. UIDs, generated names, timestamps
+// and durations, are masked.
+type FailureSignature struct {
+	Signature string   `json:"signature"`
+	Count     int      `json:"count"`
+	Suites    []string `json:"suites"`
+	// Examples lists some of the failed tests, prefixed with their suite,
+	// e.g. "storage: [test_id:1234]should restore a snapshot".
+	Examples []string `json:"examples"`
+}
+
+// resourceKinds matches the kinds of resources whose quoted names are
+// masked in failure messages.
+const resourceKinds = `pods?|pvcs?|persistentvolumeclaims?|pvs?|persistentvolumes?|dvs?|datavolumes?|datasources?|` +
+	`vmis?|virtualmachineinstances?|vms?|virtualmachines?|vmims?|virtualmachineinstancemigrations?|` +
+	`namespaces?|nodes?|services?|secrets?|configmaps?|deployments?|daemonsets?|jobs?|` +
+	`storageclasses|storageclass|volumesnapshots?|networkattachmentdefinitions?|nads?`
+
+// signatureMasks replace the run-specific parts of a failure message, in
+// order: the first ones would otherwise be broken up by the later ones.
+var signatureMasks = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uid>"},
+	{regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`\b\d{2}:\d{2}:\d{2}(?:\.\d+)?\b`), "<time>"},
+	{regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}(?::\d+)?\b`), "<ip>"},
+	{regexp.MustCompile(`\b(?:\d+(?:\.\d+)?(?:h|ms|µs|us|ns|m|s))+\b`), "<duration>"},
+	// Names generated by Kubernetes end with a dash and five characters of
+	// an alphabet without vowels, e.g. "testvmi-x7k2p" or "importer-dv-4wq8z".
+	{regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{5}\b`), "-<id>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{12,}\b`), "<id>"},
+	// Only the quoted names of resources are masked, e.g. `pvc "dv-disk"`:
+	// other quoted values, e.g. the phases and reasons Gomega compares,
+	// tell the root causes apart.
+	{regexp.MustCompile(`(?i)\b(` + resourceKinds + `) "[^"\s]+"`), `$1 "<name>"`},
+	{regexp.MustCompile(`(?i)\b(` + resourceKinds + `) '[^'\s]+'`), `$1 '<name>'`},
+	{regexp.MustCompile(`\b\d+\b`), "<n>"},
+}
+
+// normalizeFailureMessage masks the run-specific parts of a failure message,
+// so that the messages of tests that failed for the same reason are equal.
+func normalizeFailureMessage(message string) string {
+	message = strings.Join(strings.Fields(message), " ")
+	for _, mask := range signatureMasks {
+		message = mask.pattern.ReplaceAllString(message, mask.replacement)
+	}
+	return trimReason(message)
+}
+
+// failureSignatures groups the failed tests of all suites by the signature
+// of their failure message and returns the most common signatures shared by
+// at least two tests, most common first. Suites that failed during setup are
+// left out, like in the rest of the result.
+func failureSignatures(junitResults map[string]junit.TestSuite) []FailureSignature {
+	bySignature := make(map[string]*FailureSignature)
+	for _, sig := range sortedKeys(junitResults) {
+		testSuite := junitResults[sig]
+		if testSuite.SetupFailure {
+			continue
+		}
+		for _, testCase := range testSuite.TestCases {
+			if !testCase.IsFailed() {
+				continue
+			}
+			signature := normalizeFailureMessage(testCase.FailureReason())
+			if signature == "" {
+				continue
+			}
+
+			group, ok := bySignature[signature]
+			if !ok {
+				group = &FailureSignature{Signature: signature}
+				bySignature[signature] = group
+			}
+			group.Count++
+			if len(group.Suites) == 0 || group.Suites[len(group.Suites)-1] != sig {
+				group.Suites = append(group.Suites, sig)
+			}
+			if len(group.Examples) < signatureExamplesLimit {
+				group.Examples = append(group.Examples, sig+": "+testCase.Name)
+			}
+		}
+	}
+
+	var signatures []FailureSignature
+	for _, group := range bySignature {
+		if group.Count > 1 {
+			signatures = append(signatures, *group)
+		}
+	}
+	sort.Slice(signatures, func(i, j int) bool {
+		if signatures[i].Count != signatures[j].Count {
+			return signatures[i].Count > signatures[j].Count
+		}
+		return signatures[i].Signature < signatures[j].Signature
+	})
+	if len(signatures) > failureSignaturesLimit {
+		signatures = signatures[:failureSignaturesLimit]
+	}
+	return signatures
+}
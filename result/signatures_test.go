@@ -0,0 +1,122 @@
+package result_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func failedTestCase(name, message string) junit.TestCase {
+	return junit.TestCase{Name: name, Classname: "Tests Suite", Failure: &junit.Outcome{Message: message}}
+}
+
+func TestGroupsFailuresBySignature(t *testing.T) {
+	var storageTests []junit.TestCase
+	for i := 0; i < 5; i++ {
+		storageTests = append(storageTests, failedTestCase(fmt.Sprintf("should restore snapshot %d", i),
+			fmt.Sprintf("Timed out after %d.%03ds.\nPVC \"dv-%d-x7k2p\" in namespace \"test-ns-%d\" is not bound", 180+i, i, i, i)))
+	}
+	storageTests = append(storageTests, failedTestCase("should resize", "unexpected resize error"))
+
+	res := result.New(map[string]junit.TestSuite{
+		"storage": {Tests: 6, Failures: 6, TestCases: storageTests},
+		"compute": {
+			Tests:    3,
+			Failures: 3,
+			TestCases: []junit.TestCase{
+				failedTestCase("should start a VMI", "Timed out after 180.001s. PVC \"rootdisk-4wq8z\" in namespace \"kubevirt-test-default1\" is not bound"),
+				failedTestCase("should migrate", "VMI 6f1c2a4e-9b7d-4c1e-8a2f-3d5e6f7a8b9c failed at 2026-10-16T12:00:01Z after 3m0.5s"),
+				failedTestCase("should migrate again", "VMI 0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d failed at 2026-10-16T12:30:45Z after 1m2s"),
+			},
+		},
+		"ssp": {SetupFailure: true, TestCases: []junit.TestCase{failedTestCase("[BeforeSuite]", "unexpected resize error")}},
+	})
+
+	expected := []result.FailureSignature{
+		{
+			Signature: `Timed out after <duration>. PVC "<name>" in namespace "<name>" is not bound`,
+			Count:     6,
+			Suites:    []string{"compute", "storage"},
+			Examples:  []string{"compute: should start a VMI", "storage: should restore snapshot 0", "storage: should restore snapshot 1"},
+		},
+		{
+			Signature: "VMI <uid> failed at <time> after <duration>",
+			Count:     2,
+			Suites:    []string{"compute"},
+			Examples:  []string{"compute: should migrate", "compute: should migrate again"},
+		},
+	}
+	if !reflect.DeepEqual(res.Summary.FailureSignatures, expected) {
+		t.Errorf("expected failure signatures\n%+v\ngot\n%+v", expected, res.Summary.FailureSignatures)
+	}
+
+	output := res.String()
+	expectedOutput := "Top Failure Signatures:\n" +
+		"  - 6 tests in compute, storage: Timed out after <duration>. PVC \"<name>\" in namespace \"<name>\" is not bound\n" +
+		"    e.g. compute: should start a VMI\n"
+	if !strings.Contains(output, expectedOutput) {
+		t.Errorf("expected output to contain %q, got:\n%s", expectedOutput, output)
+	}
+}
+
+func TestMasksGeneratedNamesAndNumbers(t *testing.T) {
+	res := result.New(map[string]junit.TestSuite{
+		"network": {
+			Tests:    2,
+			Failures: 2,
+			TestCases: []junit.TestCase{
+				failedTestCase("a", "pod virt-launcher-testvmi-bx9zq-k2l4p unreachable from 10.128.2.15:8080, 3 retries, id 5f4dcc3b5aa765d61d8327deb882cf99"),
+				failedTestCase("b", "pod virt-launcher-testvmi-7wk2n-q8rts unreachable from 10.131.0.7:8080, 12 retries, id 0cc175b9c0f1b6a831c399e269772661"),
+			},
+		},
+	})
+
+	signatures := res.Summary.FailureSignatures
+	if len(signatures) != 1 {
+		t.Fatalf("expected the failures to share a signature, got %+v", signatures)
+	}
+	if expected := "pod virt-launcher-testvmi-<id>-<id> unreachable from <ip>, <n> retries, id <id>"; signatures[0].Signature != expected {
+		t.Errorf("expected signature %q, got %q", expected, signatures[0].Signature)
+	}
+}
+
+func TestOmitsSignaturesOfSingleFailures(t *testing.T) {
+	res := result.New(map[string]junit.TestSuite{
+		"compute": {
+			Tests:     2,
+			Failures:  2,
+			TestCases: []junit.TestCase{failedTestCase("a", "disk not found"), failedTestCase("b", "NIC not found")},
+		},
+	})
+
+	if len(res.Summary.FailureSignatures) != 0 {
+		t.Errorf("expected no failure signature, got %+v", res.Summary.FailureSignatures)
+	}
+}
+
+func TestKeepsQuotedValuesThatAreNotResourceNames(t *testing.T) {
+	var testCases []junit.TestCase
+	for i, phase := range []string{"ImagePullBackOff", "ImagePullBackOff", "Unschedulable", "Unschedulable"} {
+		testCases = append(testCases, failedTestCase(fmt.Sprintf("should start %d", i),
+			fmt.Sprintf("pod \"virt-launcher-testvmi-%d\": Expected <string>: \"%s\" to equal \"Running\"", i, phase)))
+	}
+	res := result.New(map[string]junit.TestSuite{
+		"compute": {Tests: 4, Failures: 4, TestCases: testCases},
+	})
+
+	var signatures []string
+	for _, signature := range res.Summary.FailureSignatures {
+		signatures = append(signatures, signature.Signature)
+	}
+	expected := []string{
+		`pod "<name>": Expected <string>: "ImagePullBackOff" to equal "Running"`,
+		`pod "<name>": Expected <string>: "Unschedulable" to equal "Running"`,
+	}
+	if !reflect.DeepEqual(signatures, expected) {
+		t.Errorf("expected signatures %q, got %q", expected, signatures)
+	}
+}
@@ -0,0 +1,61 @@
+package result
+
+import (
+	"strings"
+
+	"junitparser/junit_parser/junit"
+)
+
+// SkipReasonSkipList is the reason of the tests that were not selected
+// because they are on the dont_run or quarantine lists of the run, rather
+// than because of its label filter.
+const SkipReasonSkipList = "excluded by the dont_run and quarantine lists"
+
+// FlagSkipListedTests moves the skipped tests of the given suites that were
+// not selected by the run filters and match an entry of the skip lists, e.g.
+// the dont_run_tests.json and quarantined_tests.json catalogs of the suites
+// run by test-kubevirt.sh, to their own skip reason. An entry matches the
+// tests whose name contains its id, as the lists are passed to Ginkgo as skip
+// expressions. The other suites are left alone, as the lists were not
+// applied to them.
+func (r *Result) FlagSkipListedTests(skipLists KnownIssues, sigs []string, junitResults map[string]junit.TestSuite) {
+	for _, sig := range sigs {
+		sigRes, ok := r.SigMap[sig]
+		if !ok {
+			continue
+		}
+		testSuite, ok := junitResults[sig]
+		if !ok {
+			continue
+		}
+
+		for _, testCase := range testSuite.TestCases {
+			if testCase.SkipReason() != junit.SkipReasonNotSelected || !skipLists.skipListed(testCase.Name) {
+				continue
+			}
+			sigRes.SkippedByReason[junit.SkipReasonNotSelected]--
+			if sigRes.SkippedByReason[junit.SkipReasonNotSelected] == 0 {
+				delete(sigRes.SkippedByReason, junit.SkipReasonNotSelected)
+			}
+			sigRes.SkippedByReason[SkipReasonSkipList]++
+		}
+
+		r.SigMap[sig] = sigRes
+	}
+}
+
+// skipListed reports whether a test is excluded by an entry of the skip
+// lists, the way the Ginkgo skip expressions built from them match it.
+func (k KnownIssues) skipListed(testName string) bool {
+	for _, issue := range k {
+		switch {
+		case issue.regex != nil:
+			if issue.regex.MatchString(testName) {
+				return true
+			}
+		case issue.ID != "" && strings.Contains(testName, issue.ID):
+			return true
+		}
+	}
+	return false
+}
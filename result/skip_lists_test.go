@@ -0,0 +1,52 @@
+package result_test
+
+import (
+	"reflect"
+	"testing"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func TestFlagSkipListedTests(t *testing.T) {
+	skipLists, err := result.LoadKnownIssues(writeKnownIssues(t, `[
+  {"id": "Slirp Networking", "reason": "Slirp tests don't work with QEMU from CNV D/S"},
+  {"id": "test_id:1783", "reason": "Tracked in https://issues.redhat.com/browse/CNV-45625"}
+]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	junitRes := map[string]junit.TestSuite{
+		"network": {
+			Tests:   4,
+			Skipped: 4,
+			TestCases: []junit.TestCase{
+				{Name: "[sig-network] Slirp Networking should start", Skipped: &junit.Outcome{}},
+				{Name: "[test_id:1783] should migrate", Skipped: &junit.Outcome{}},
+				{Name: "[sig-network] SRIOV should attach", Skipped: &junit.Outcome{}},
+				{Name: "[sig-network] Slirp Networking should ping", Skipped: &junit.Outcome{Message: "no IPv6"}},
+			},
+		},
+		"tier2": {
+			Tests:     1,
+			Skipped:   1,
+			TestCases: []junit.TestCase{{Name: "test_slirp_networking[test_id:1783]", Skipped: &junit.Outcome{}}},
+		},
+	}
+	res := result.New(junitRes)
+
+	res.FlagSkipListedTests(skipLists, []string{"compute", "network", "storage"}, junitRes)
+
+	expected := map[string]int{
+		result.SkipReasonSkipList:   2,
+		junit.SkipReasonNotSelected: 1,
+		"no IPv6":                   1,
+	}
+	if got := res.SigMap["network"].SkippedByReason; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected skip reasons %v, got %v", expected, got)
+	}
+	if got := res.SigMap["tier2"].SkippedByReason; !reflect.DeepEqual(got, map[string]int{junit.SkipReasonNotSelected: 1}) {
+		t.Errorf("expected the skip lists not to apply to tier2, got %v", got)
+	}
+}
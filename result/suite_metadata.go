@@ -0,0 +1,101 @@
+package result
+
+import (
+	"strings"
+
+	"junitparser/junit_parser/junit"
+)
+
+// SuiteMetadata describes how a suite was run, as recorded in the
+// <properties> of its JUnit file, so that the run can be reproduced from the
+// results alone.
+type SuiteMetadata struct {
+	LabelFilter      string `json:"label_filter,omitempty"`
+	Focus            string `json:"focus,omitempty"`
+	Skip             string `json:"skip,omitempty"`
+	RandomSeed       string `json:"random_seed,omitempty"`
+	FrameworkVersion string `json:"framework_version,omitempty"`
+	// Properties holds the remaining non-empty suite properties, e.g. the
+	// pytest environment data.
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// frameworkVersionProperties are the property names, lowercased and without
+// separators, that hold the version of the test framework.
+var frameworkVersionProperties = map[string]bool{
+	"frameworkversion": true,
+	"ginkgoversion":    true,
+	"pytestversion":    true,
+}
+
+// newSuiteMetadata builds the metadata of a suite from its properties.
+// Returns nil when the suite has no properties.
+func newSuiteMetadata(testSuite junit.TestSuite) *SuiteMetadata {
+	if len(testSuite.Properties) == 0 {
+		return nil
+	}
+
+	md := &SuiteMetadata{}
+	for _, property := range testSuite.Properties {
+		value := strings.TrimSpace(property.Value)
+		if value == "" {
+			continue
+		}
+
+		switch {
+		case property.Name == "LabelFilter":
+			md.LabelFilter = value
+		case property.Name == "FocusStrings":
+			md.Focus = value
+		case property.Name == "SkipStrings":
+			md.Skip = value
+		case property.Name == "RandomSeed":
+			md.RandomSeed = value
+		case frameworkVersionProperties[normalizePropertyName(property.Name)]:
+			if md.FrameworkVersion == "" {
+				md.FrameworkVersion = value
+			}
+		default:
+			if md.Properties == nil {
+				md.Properties = make(map[string]string)
+			}
+			if _, ok := md.Properties[property.Name]; !ok {
+				md.Properties[property.Name] = value
+			}
+		}
+	}
+
+	return md
+}
+
+func normalizePropertyName(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(name)
+}
+
+// metadataField is a labelled setting of a suite run.
+type metadataField struct {
+	Label, Value string
+}
+
+// runSettings returns the non-empty settings needed to reproduce the suite's
+// run. The remaining properties are only kept in the ConfigMap.
+func (md *SuiteMetadata) runSettings() []metadataField {
+	if md == nil {
+		return nil
+	}
+
+	var fields []metadataField
+	for _, field := range []metadataField{
+		{"Label Filter", md.LabelFilter},
+		{"Focus", md.Focus},
+		{"Skip", md.Skip},
+		{"Random Seed", md.RandomSeed},
+		{"Framework Version", md.FrameworkVersion},
+	} {
+		if field.Value != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
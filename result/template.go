@@ -0,0 +1,109 @@
+package result
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed summary.txt.tmpl
+var summaryTemplateText string
+
+// summaryTemplate is the built-in layout of the text summary.
+var summaryTemplate = template.Must(NewTemplate("summary", summaryTemplateText))
+
+// templateFuncs are the helper functions available to the summary templates.
+var templateFuncs = template.FuncMap{
+	// banner frames a header between two lines of "=" of the same length.
+	"banner": func(header string) string {
+		separator := strings.Repeat("=", len(header))
+		return separator + "\n" + header + "\n" + separator
+	},
+	// keys returns the keys of a map with string keys, sorted.
+	"keys": templateKeys,
+	// byCount returns the keys of a map of counts, most frequent first.
+	"byCount": func(counts map[string]int) []string {
+		keys := sortedKeys(counts)
+		sort.SliceStable(keys, func(i, j int) bool {
+			return counts[keys[i]] > counts[keys[j]]
+		})
+		return keys
+	},
+	// sortedCrits returns the criticality values of a group, most critical first.
+	"sortedCrits": sortedCrits,
+	// flat reports whether the failed tests have no category.
+	"flat": func(failedTests FailedTestsMap) bool {
+		return failedTests.isFlat()
+	},
+	"runSettings":       (*SuiteMetadata).runSettings,
+	"incompleteWarning": incompleteWarning,
+	// percent returns part as a percentage of total, e.g. "87.5".
+	"percent": func(part, total int) string {
+		if total == 0 {
+			return "0"
+		}
+		return formatPercentage(float64(part) * 100 / float64(total))
+	},
+	// signed formats a count difference with an explicit sign when positive.
+	"signed": signedCount,
+	"join":   strings.Join,
+	"repeat": strings.Repeat,
+	"lower":  strings.ToLower,
+	"upper":  strings.ToUpper,
+}
+
+// NewTemplate parses a summary template with the helper functions.
+func NewTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Parse(text)
+}
+
+// LoadTemplate reads and parses the summary template stored in fileName.
+func LoadTemplate(fileName string) (*template.Template, error) {
+	text, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file %s; %w", fileName, err)
+	}
+
+	tmpl, err := NewTemplate(fileName, string(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template file %s; %w", fileName, err)
+	}
+	return tmpl, nil
+}
+
+// templateData is what the summary templates are rendered against: the
+// result, along with the timestamp of the run.
+type templateData struct {
+	Result
+	Timestamp string
+}
+
+// RenderTemplate renders the result with a summary template.
+func (r Result) RenderTemplate(tmpl *template.Template) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData{Result: r, Timestamp: os.Getenv("TIMESTAMP")}); err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %w", tmpl.Name(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+// templateKeys returns the sorted keys of a map with string keys, or nil
+// for any other value.
+func templateKeys(m any) []string {
+	value := reflect.ValueOf(m)
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+
+	keys := make([]string, 0, value.Len())
+	for _, key := range value.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
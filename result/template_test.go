@@ -0,0 +1,69 @@
+package result_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"junitparser/result"
+)
+
+func writeTemplate(t *testing.T, text string) string {
+	t.Helper()
+
+	fileName := filepath.Join(t.TempDir(), "summary.tmpl")
+	if err := os.WriteFile(fileName, []byte(text), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	return fileName
+}
+
+func TestRendersUserTemplate(t *testing.T) {
+	t.Setenv("TIMESTAMP", "20261016-120000")
+
+	tmpl, err := result.LoadTemplate(writeTemplate(t, `Validation {{.Timestamp}}: {{.Summary.Passed}}/{{.Summary.Run}} passed ({{percent .Summary.Passed .Summary.Run}}%)
+{{range $sig := keys .SigMap}}{{with index $.SigMap $sig}}{{if .Failures}}- {{upper $sig}}: {{.Failures}} failed{{range $cat := keys .FailedTests}} [{{or $cat "uncategorized"}}]{{end}}
+{{end}}{{end}}{{end}}{{range $sig := keys .Summary.SuiteStatuses}}{{if not (index $.SigMap $sig).Run}}- {{$sig}}: {{index $.Summary.SuiteStatuses $sig}}
+{{end}}{{end}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rendered, err := goldenResult().RenderTemplate(tmpl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "Validation 20261016-120000: 3/5 passed (60%)\n" +
+		"- COMPUTE: 1 failed [uncategorized]\n" +
+		"- TIER2: 1 failed [virt/node]\n" +
+		"- network: not-started\n" +
+		"- ssp: setup-failed\n"
+	if string(rendered) != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, rendered)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	if _, err := result.LoadTemplate(writeTemplate(t, "{{.Summary.Run")); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+	if _, err := result.LoadTemplate(writeTemplate(t, "{{unknownFunc .}}")); err == nil {
+		t.Error("expected an error for an unknown function")
+	}
+	if _, err := result.LoadTemplate(writeTemplate(t, `{{env "KUBECONFIG"}}`)); err == nil {
+		t.Error("expected the environment of the checkup not to be available to templates")
+	}
+	if _, err := result.LoadTemplate("/does/not/exist.tmpl"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+
+	tmpl, err := result.NewTemplate("summary", "{{.Summary.NoSuchField}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := goldenResult().RenderTemplate(tmpl); err == nil || !strings.Contains(err.Error(), "NoSuchField") {
+		t.Errorf("expected an error for an unknown field, got %v", err)
+	}
+}
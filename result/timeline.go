@@ -0,0 +1,121 @@
+package result
+
+import (
+	"time"
+
+	"junitparser/junit_parser/junit"
+)
+
+// Phase is a step of a suite run or of the whole run, as recorded by the
+// runner scripts, e.g. the rollout of the disks-images-provider, the tests
+// themselves or the cleanup of the test namespaces.
+type Phase struct {
+	Name  string `json:"name"`
+	Start string `json:"start"`
+	// End and Duration are empty when the phase never ended, e.g. when the
+	// run was killed during it.
+	End      string `json:"end,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// AddTimeline sets the phases of every suite and of the whole run, along
+// with their wall-clock duration, from the first phase start to the last
+// phase end, and the time spent in the tests themselves. The difference
+// between the two is the time that went into setting up and cleaning up.
+// Suites without recorded phases get no timeline, and the phases of the
+// suites that failed during setup only count in the run's wall-clock time.
+func (r *Result) AddTimeline(runPhases []junit.Phase, junitResults map[string]junit.TestSuite) {
+	var total span
+	var totalTestTime float64
+	for sig, testSuite := range junitResults {
+		var suiteSpan span
+		for _, phase := range testSuite.Phases {
+			suiteSpan.add(phase)
+		}
+		total.merge(suiteSpan)
+
+		sigRes, ok := r.SigMap[sig]
+		if !ok {
+			continue
+		}
+		testTime := summedTestTime(testSuite.TestCases)
+		totalTestTime += testTime
+		if len(testSuite.Phases) == 0 {
+			continue
+		}
+
+		for _, phase := range testSuite.Phases {
+			sigRes.Phases = append(sigRes.Phases, newPhase(phase))
+		}
+		sigRes.WallClock = suiteSpan.duration()
+		sigRes.TestTime = formatDuration(testTime)
+		r.SigMap[sig] = sigRes
+	}
+
+	for _, phase := range runPhases {
+		r.Summary.Phases = append(r.Summary.Phases, newPhase(phase))
+		total.add(phase)
+	}
+	if wallClock := total.duration(); wallClock != "" {
+		r.Summary.WallClock = wallClock
+		r.Summary.TestTime = formatDuration(totalTestTime)
+	}
+}
+
+func newPhase(phase junit.Phase) Phase {
+	res := Phase{
+		Name:  phase.Name,
+		Start: phase.Start.UTC().Format(time.RFC3339),
+	}
+	if !phase.End.IsZero() {
+		res.End = phase.End.UTC().Format(time.RFC3339)
+		res.Duration = formatPhaseDuration(phase.Duration())
+	}
+	return res
+}
+
+// formatPhaseDuration formats the duration of a phase, rounded to whole
+// seconds like the test durations. Unlike those, a phase that took less
+// than half a second is reported as "0s", since it did run.
+func formatPhaseDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+// summedTestTime returns the time spent in the test cases of a suite.
+func summedTestTime(testCases []junit.TestCase) float64 {
+	var seconds float64
+	for _, testCase := range testCases {
+		seconds += testCase.Time
+	}
+	return seconds
+}
+
+// span is the time from the first start to the last end of some phases.
+type span struct {
+	start, end time.Time
+}
+
+func (s *span) add(phase junit.Phase) {
+	if s.start.IsZero() || phase.Start.Before(s.start) {
+		s.start = phase.Start
+	}
+	if phase.End.After(s.end) {
+		s.end = phase.End
+	}
+}
+
+func (s *span) merge(other span) {
+	if other.start.IsZero() {
+		return
+	}
+	s.add(junit.Phase{Start: other.start, End: other.end})
+}
+
+// duration returns the formatted length of the span, or an empty string
+// when none of its phases ended.
+func (s span) duration() string {
+	if s.start.IsZero() || !s.end.After(s.start) {
+		return ""
+	}
+	return formatPhaseDuration(s.end.Sub(s.start))
+}
@@ -0,0 +1,81 @@
+package result_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func TestAddsTimeline(t *testing.T) {
+	at := func(minutes int) time.Time {
+		return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC).Add(time.Duration(minutes) * time.Minute)
+	}
+
+	junitResults := map[string]junit.TestSuite{
+		"storage": {
+			Tests: 2,
+			TestCases: []junit.TestCase{
+				{Name: "clone", Time: 600},
+				{Name: "snapshot", Time: 900},
+			},
+			Phases: []junit.Phase{
+				{Name: "setup", Start: at(0), End: at(1)},
+				{Name: "disk-images-provider", Start: at(1), End: at(6)},
+				{Name: "tests", Start: at(6), End: at(36)},
+				{Name: "disk-images-provider-cleanup", Start: at(36), End: at(37)},
+			},
+		},
+		"network": {
+			Tests:     1,
+			TestCases: []junit.TestCase{{Name: "bridge", Time: 60}},
+		},
+		"ssp": {
+			SetupFailure: true,
+			Phases: []junit.Phase{
+				{Name: "setup", Start: at(40), End: at(41)},
+				{Name: "tests", Start: at(41)},
+			},
+		},
+	}
+	runPhases := []junit.Phase{{Name: "windows-image-setup", Start: at(-20), End: at(-1)}}
+
+	res := result.New(junitResults)
+	res.AddTimeline(runPhases, junitResults)
+
+	storage := res.SigMap["storage"]
+	if storage.WallClock != "37m0s" || storage.TestTime != "25m0s" {
+		t.Errorf("expected a wall-clock of 37m0s with 25m0s of tests, got %s with %s", storage.WallClock, storage.TestTime)
+	}
+	expected := result.Phase{Name: "disk-images-provider", Start: "2026-10-16T12:01:00Z", End: "2026-10-16T12:06:00Z", Duration: "5m0s"}
+	if len(storage.Phases) != 4 || !reflect.DeepEqual(storage.Phases[1], expected) {
+		t.Errorf("expected the phases of the suite, got %+v", storage.Phases)
+	}
+
+	if network := res.SigMap["network"]; network.Phases != nil || network.WallClock != "" || network.TestTime != "" {
+		t.Errorf("expected no timeline for a suite without phases, got %+v", network)
+	}
+
+	summary := res.Summary
+	if summary.WallClock != "1h1m0s" || summary.TestTime != "26m0s" {
+		t.Errorf("expected a total wall-clock of 1h1m0s with 26m0s of tests, got %s with %s", summary.WallClock, summary.TestTime)
+	}
+	if len(summary.Phases) != 1 || summary.Phases[0].Name != "windows-image-setup" || summary.Phases[0].Duration != "19m0s" {
+		t.Errorf("expected the run phases in the summary, got %+v", summary.Phases)
+	}
+}
+
+func TestAddsNoTimelineWithoutPhases(t *testing.T) {
+	junitResults := map[string]junit.TestSuite{
+		"compute": {Tests: 1, TestCases: []junit.TestCase{{Name: "migrate", Time: 60}}},
+	}
+
+	res := result.New(junitResults)
+	res.AddTimeline(nil, junitResults)
+
+	if res.Summary.WallClock != "" || res.Summary.TestTime != "" || res.SigMap["compute"].WallClock != "" {
+		t.Errorf("expected no timeline, got %+v", res.Summary)
+	}
+}
@@ -0,0 +1,192 @@
+package result
+
+import "sort"
+
+// Truncate returns a copy of the result in which every list of a suite
+// holds at most limit entries, and the failure reasons and test IDs are
+// only kept for the failed tests that are. The skip reasons are cut down to
+// the limit most common ones, and the lists of tests of the comparison with
+// the baseline to limit entries as well. The number of entries left out of
+// each list is recorded in the Omitted map of the suite or of its
+// comparison. It keeps the results ConfigMap under the size limit of the API
+// server when a run fails a lot of tests.
+func (r Result) Truncate(limit int) Result {
+	truncated := r
+	truncated.SigMap = make(SigMap, len(r.SigMap))
+	for sig, sigRes := range r.SigMap {
+		truncated.SigMap[sig] = sigRes.truncate(limit)
+	}
+	if r.Comparison != nil {
+		truncated.Comparison = r.Comparison.truncate(limit)
+	}
+	return truncated
+}
+
+// Minimal returns a copy of the result that only keeps the counts, status
+// and score of the run and of its suites, and the verdict of its policy.
+// It is stored when even the result truncated by Truncate does not fit in
+// its ConfigMap.
+func (r Result) Minimal() Result {
+	truncated := r.Truncate(0)
+	minimal := Result{
+		APIVersion:   r.APIVersion,
+		SigMap:       make(SigMap, len(r.SigMap)),
+		Summary:      r.Summary,
+		SetupFailure: r.SetupFailure,
+		Comparison:   truncated.Comparison,
+	}
+	minimal.Summary.SlowestTests = nil
+	minimal.Summary.Phases = nil
+	minimal.Summary.FailureSignatures = nil
+	for sig, sigRes := range truncated.SigMap {
+		minimal.SigMap[sig] = Sig{
+			Run:        sigRes.Run,
+			Passed:     sigRes.Passed,
+			Failures:   sigRes.Failures,
+			Skipped:    sigRes.Skipped,
+			Duration:   sigRes.Duration,
+			Flaky:      sigRes.Flaky,
+			Incomplete: sigRes.Incomplete,
+			Expected:   sigRes.Expected,
+			Status:     sigRes.Status,
+			Readiness:  sigRes.Readiness,
+			WallClock:  sigRes.WallClock,
+			TestTime:   sigRes.TestTime,
+			Omitted:    sigRes.Omitted,
+		}
+	}
+	return minimal
+}
+
+// IsTruncated reports whether entries were left out of the lists of tests of
+// a suite by Truncate.
+func (r Result) IsTruncated() bool {
+	for _, sigRes := range r.SigMap {
+		if len(sigRes.Omitted) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Sig) truncate(limit int) Sig {
+	omitted := make(map[string]int)
+	for name, count := range s.Omitted {
+		omitted[name] = count
+	}
+
+	if len(s.FailedTests) > 0 {
+		kept := make(map[string]bool)
+		failedTests := make(FailedTestsMap)
+		budget := limit
+		for _, category := range sortedKeys(s.FailedTests) {
+			names := s.FailedTests[category]
+			n := min(len(names), budget)
+			budget -= n
+			omitted["failed_tests"] += len(names) - n
+			if n == 0 {
+				continue
+			}
+			failedTests[category] = names[:n]
+			for _, name := range names[:n] {
+				kept[name] = true
+			}
+		}
+		s.FailedTests = nil
+		if len(failedTests) > 0 {
+			s.FailedTests = failedTests
+		}
+
+		s.FailureReasons = keepTests(s.FailureReasons, func(name, _ string) bool { return kept[name] })
+		s.FailedTestIDs = keepTests(s.FailedTestIDs, func(_, name string) bool { return kept[name] })
+	}
+
+	s.FlakyTests = truncateList(s.FlakyTests, limit, "flaky_tests", omitted)
+	s.KnownFailures = truncateList(s.KnownFailures, limit, "known_failures", omitted)
+	s.NewFailures = truncateList(s.NewFailures, limit, "new_failures", omitted)
+	s.SubSuites = truncateList(s.SubSuites, limit, "sub_suites", omitted)
+	s.SkippedByReason = truncateCounts(s.SkippedByReason, limit, "skipped_by_reason", omitted)
+
+	s.Omitted = nonZero(omitted)
+	return s
+}
+
+func (c *Comparison) truncate(limit int) *Comparison {
+	truncated := *c
+	truncated.Suites = make(map[string]SuiteComparison, len(c.Suites))
+	for name, suite := range c.Suites {
+		omitted := make(map[string]int)
+		for list, count := range suite.Omitted {
+			omitted[list] = count
+		}
+		suite.NewlyFailing = truncateList(suite.NewlyFailing, limit, "newly_failing", omitted)
+		suite.NewlyPassing = truncateList(suite.NewlyPassing, limit, "newly_passing", omitted)
+		suite.Disappeared = truncateList(suite.Disappeared, limit, "disappeared", omitted)
+		suite.Omitted = nonZero(omitted)
+		truncated.Suites[name] = suite
+	}
+	return &truncated
+}
+
+// truncateCounts returns the limit entries of counts with the highest
+// counts, ties broken by key, adding the number of entries left out to
+// omitted[name].
+func truncateCounts(counts map[string]int, limit int, name string, omitted map[string]int) map[string]int {
+	if len(counts) <= limit {
+		return counts
+	}
+	keys := sortedKeys(counts)
+	sort.SliceStable(keys, func(i, j int) bool { return counts[keys[i]] > counts[keys[j]] })
+	omitted[name] += len(keys) - limit
+	if limit == 0 {
+		return nil
+	}
+	kept := make(map[string]int, limit)
+	for _, key := range keys[:limit] {
+		kept[key] = counts[key]
+	}
+	return kept
+}
+
+// nonZero returns the entries of omitted with a non-zero count, or nil when
+// there are none.
+func nonZero(omitted map[string]int) map[string]int {
+	for name, count := range omitted {
+		if count == 0 {
+			delete(omitted, name)
+		}
+	}
+	if len(omitted) == 0 {
+		return nil
+	}
+	return omitted
+}
+
+// truncateList returns the first limit entries of list, adding the number
+// of entries left out to omitted[name].
+func truncateList[T any](list []T, limit int, name string, omitted map[string]int) []T {
+	if len(list) <= limit {
+		return list
+	}
+	omitted[name] += len(list) - limit
+	if limit == 0 {
+		return nil
+	}
+	return list[:limit]
+}
+
+// keepTests returns the entries of a map keyed by test name or test ID for
+// which keep returns true, or nil when there are none.
+func keepTests(m map[string]string, keep func(key, value string) bool) map[string]string {
+	var kept map[string]string
+	for key, value := range m {
+		if !keep(key, value) {
+			continue
+		}
+		if kept == nil {
+			kept = make(map[string]string)
+		}
+		kept[key] = value
+	}
+	return kept
+}
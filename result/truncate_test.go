@@ -0,0 +1,109 @@
+package result_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"junitparser/junit_parser/junit"
+	"junitparser/result"
+)
+
+func TestTruncatesTestLists(t *testing.T) {
+	var testCases []junit.TestCase
+	for i := 0; i < 5; i++ {
+		testCases = append(testCases, junit.TestCase{
+			Name:      fmt.Sprintf("test_%d", i),
+			Classname: "tests.storage.test_snapshot",
+			Failure:   &junit.Outcome{Message: "timed out"},
+			Metadata:  junit.Metadata{TestIDs: []string{fmt.Sprint(1000 + i)}},
+		})
+	}
+	testCases = append(testCases, junit.TestCase{Name: "test_bridge", Classname: "tests.network.test_bridge", Error: &junit.Outcome{Message: "setup failed"}})
+	testCases = append(testCases, junit.TestCase{Name: "test_flaky", Classname: "tests.network.test_bridge", Attempts: 2})
+
+	res := result.New(map[string]junit.TestSuite{
+		"tier2": {Tests: 7, Failures: 5, Errors: 1, TestCases: testCases},
+	})
+	truncated := res.Truncate(3)
+
+	sigRes := truncated.SigMap["tier2"]
+	expectedFailedTests := result.FailedTestsMap{"network": {"test_bridge"}, "storage": {"test_0", "test_1"}}
+	if !reflect.DeepEqual(sigRes.FailedTests, expectedFailedTests) {
+		t.Errorf("expected failed tests %v, got %v", expectedFailedTests, sigRes.FailedTests)
+	}
+	if len(sigRes.FailureReasons) != 3 || len(sigRes.FailedTestIDs) != 2 {
+		t.Errorf("expected the reasons and IDs of the kept tests only, got %v and %v", sigRes.FailureReasons, sigRes.FailedTestIDs)
+	}
+	if expected := map[string]int{"failed_tests": 3}; !reflect.DeepEqual(sigRes.Omitted, expected) {
+		t.Errorf("expected omitted counts %v, got %v", expected, sigRes.Omitted)
+	}
+	if sigRes.Failures != 6 || len(sigRes.FlakyTests) != 1 {
+		t.Errorf("expected the counts and the shorter lists to be kept, got %+v", sigRes)
+	}
+
+	if original := res.SigMap["tier2"]; len(original.FailedTests["storage"]) != 5 || original.Omitted != nil {
+		t.Errorf("expected the original result to be left untouched, got %+v", original)
+	}
+
+	empty := res.Truncate(0).SigMap["tier2"]
+	if empty.FailedTests != nil || empty.FailureReasons != nil || empty.FlakyTests != nil {
+		t.Errorf("expected no lists of tests, got %+v", empty)
+	}
+	if expected := map[string]int{"failed_tests": 6, "flaky_tests": 1}; !reflect.DeepEqual(empty.Omitted, expected) {
+		t.Errorf("expected omitted counts %v, got %v", expected, empty.Omitted)
+	}
+}
+
+func TestTruncatesSkipReasonsSubSuitesAndComparison(t *testing.T) {
+	res := result.Result{
+		SigMap: result.SigMap{
+			"tier2": {
+				Skipped:         6,
+				SkippedByReason: map[string]int{"no GPU": 3, "not selected by the run filters": 2, "no SR-IOV": 1},
+				SubSuites:       []result.SubSuite{{Name: "network"}, {Name: "storage"}, {Name: "compute"}},
+			},
+		},
+		Comparison: &result.Comparison{
+			Baseline: "previous",
+			Suites: map[string]result.SuiteComparison{
+				"tier2": {
+					Deltas:       result.TestCounts{Failures: 3},
+					NewlyFailing: []string{"test_a", "test_b", "test_c"},
+					NewlyPassing: []string{"test_d"},
+				},
+			},
+		},
+	}
+	truncated := res.Truncate(2)
+
+	sigRes := truncated.SigMap["tier2"]
+	if expected := map[string]int{"no GPU": 3, "not selected by the run filters": 2}; !reflect.DeepEqual(sigRes.SkippedByReason, expected) {
+		t.Errorf("expected the most common skip reasons %v, got %v", expected, sigRes.SkippedByReason)
+	}
+	if len(sigRes.SubSuites) != 2 {
+		t.Errorf("expected 2 sub-suites, got %v", sigRes.SubSuites)
+	}
+	if expected := map[string]int{"skipped_by_reason": 1, "sub_suites": 1}; !reflect.DeepEqual(sigRes.Omitted, expected) {
+		t.Errorf("expected omitted counts %v, got %v", expected, sigRes.Omitted)
+	}
+
+	suite := truncated.Comparison.Suites["tier2"]
+	if !reflect.DeepEqual(suite.NewlyFailing, []string{"test_a", "test_b"}) || len(suite.NewlyPassing) != 1 {
+		t.Errorf("expected the newly failing tests to be truncated, got %+v", suite)
+	}
+	if expected := map[string]int{"newly_failing": 1}; !reflect.DeepEqual(suite.Omitted, expected) {
+		t.Errorf("expected omitted counts %v, got %v", expected, suite.Omitted)
+	}
+	if original := res.Comparison.Suites["tier2"]; len(original.NewlyFailing) != 3 || original.Omitted != nil {
+		t.Errorf("expected the original comparison to be left untouched, got %+v", original)
+	}
+
+	minimal := res.Minimal()
+	if sigRes := minimal.SigMap["tier2"]; sigRes.Skipped != 6 || sigRes.SkippedByReason != nil || sigRes.SubSuites != nil {
+		t.Errorf("expected only the counts to be kept, got %+v", sigRes)
+	}
+	if suite := minimal.Comparison.Suites["tier2"]; suite.Deltas.Failures != 3 || suite.NewlyFailing != nil || suite.NewlyPassing != nil {
+		t.Errorf("expected only the deltas of the comparison to be kept, got %+v", suite)
+	}
+}
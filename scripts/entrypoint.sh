@@ -397,7 +397,9 @@ if [ "${ACCEPT_WINDOWS_EULA}" == "true" ]; then
     export WIN_IMAGE_NAME
     export TEKTON_PIPELINE_VERSION
     export ACCEPT_WINDOWS_EULA
+    tests::phase start windows-image-setup "${RESULTS_DIR}"
     bash "${WINDOWS_SETUP_SCRIPT}"
+    tests::phase end windows-image-setup "${RESULTS_DIR}"
   else
     echo "Warning: Windows setup script not found at ${WINDOWS_SETUP_SCRIPT}"
   fi
@@ -503,16 +505,87 @@ fi
 # =========
 # Summarize
 # =========
-PARSER_EXIT=0
-junit_parser --results-dir=${RESULTS_DIR}  --start-timestamp=${START_TIMESTAMP} --completion-timestamp=${COMPLETION_TIMESTAMP} | tee ${RESULTS_DIR}/summary-log.txt || PARSER_EXIT=$?
+RESULT_SOURCES_FLAG=""
+if [ -n "${RESULT_SOURCES}" ]
+then
+  RESULT_SOURCES_FLAG="--result-sources=${RESULT_SOURCES}"
+fi
+
+BASELINE_FLAG=""
+if [ -n "${BASELINE}" ]
+then
+  BASELINE_FLAG="--baseline=${BASELINE}"
+fi
+
+# The compute quarantine and skip lists exclude tests from the run
+SKIP_LISTS_FLAG="--skip-lists=${SCRIPT_DIR}/kubevirt/config/quarantined_tests.json,${SCRIPT_DIR}/kubevirt/config/dont_run_tests.json"
+
+# The quarantined tests are skipped, so they never fail: only a catalog of
+# the issues of tests that do run can tell the known failures apart
+KNOWN_ISSUES_FLAG=""
+if [ -n "${KNOWN_ISSUES}" ]
+then
+  KNOWN_ISSUES_FLAG="--known-issues=${KNOWN_ISSUES}"
+fi
+
+POLICY_FLAG=""
+if [ -n "${POLICY}" ]
+then
+  POLICY_FLAG="--policy=${POLICY}"
+fi
+
+TEMPLATE_FLAG=""
+if [ -n "${SUMMARY_TEMPLATE}" ]
+then
+  TEMPLATE_FLAG="--template=${SUMMARY_TEMPLATE}"
+fi
+
+SCORE_WEIGHTS_FLAG=""
+if [ -n "${SCORE_WEIGHTS}" ]
+then
+  SCORE_WEIGHTS_FLAG="--score-weights=${SCORE_WEIGHTS}"
+fi
+
+# The XML exports record the CNV version the tests ran against. The merged
+# JUnit XML is not named junit*.xml, as those are the files of the suites.
+CNV_VERSION=${CSV_VERSION:-$(oc get csv -n openshift-cnv -o json | jq -r '.items[] | select(.metadata.name | startswith("kubevirt-hyperconverged")).spec.version')}
+EXPORT_FLAGS="--cnv-version=${CNV_VERSION}"
+if [ "${MERGED_JUNIT}" == "true" ]
+then
+  EXPORT_FLAGS="${EXPORT_FLAGS} --junit-export=merged-results.xml"
+fi
+if [ -n "${POLARION_PROJECT}" ]
+then
+  EXPORT_FLAGS="${EXPORT_FLAGS} --polarion-export=polarion.xml --polarion-project=${POLARION_PROJECT}"
+fi
+
+# Take the exit code of junit_parser rather than the one of tee
+set +e
+junit_parser --results-dir=${RESULTS_DIR}  --start-timestamp=${START_TIMESTAMP} --completion-timestamp=${COMPLETION_TIMESTAMP} --expected-suites=${TEST_SUITES} ${RESULT_SOURCES_FLAG} ${BASELINE_FLAG} ${POLICY_FLAG} ${KNOWN_ISSUES_FLAG} ${SKIP_LISTS_FLAG} ${TEMPLATE_FLAG} ${SCORE_WEIGHTS_FLAG} ${EXPORT_FLAGS} | tee ${RESULTS_DIR}/summary-log.txt
+PARSER_EXIT=${PIPESTATUS[0]}
+set -e
 
-# Archive test results into tar.gz (exclude .dry-run directory as a defensive measure)
+# Archive test results into tar.gz, including the report.html and XML exports written by junit_parser
+# (exclude .dry-run directory as a defensive measure)
 tar -czf /tmp/test-results-${TIMESTAMP}.tar.gz -C ${RESULTS_DIR} --exclude='.dry-run' .
 mv /tmp/test-results-${TIMESTAMP}.tar.gz ${RESULTS_DIR}/test-results-${TIMESTAMP}.tar.gz
 
-if [ ${PARSER_EXIT} -ne 0 ]; then
-  echo "Self Validation test run finished with errors (setup failure detected, no ConfigMap created)."
-  exit 1
-fi
+# A failed or inconclusive policy verdict is the exit code of the container,
+# so that automation can gate on the Job; the verdict is also kept in the ConfigMap.
+case ${PARSER_EXIT} in
+  0) ;;
+  2)
+    echo "Self Validation policy verdict: failed."
+    exit 2
+    ;;
+  3)
+    echo "Self Validation policy verdict: inconclusive."
+    exit 3
+    ;;
+  *)
+    echo "Self Validation test run finished with errors (setup failure detected, no ConfigMap created)."
+    exit 1
+    ;;
+esac
 
 echo "Self Validation test run is done."
\ No newline at end of file
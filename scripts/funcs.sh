@@ -290,6 +290,20 @@ tests::monitoring::get_prometheus_namespace() {
     --ignore-not-found
 }
 
+#
+# Records the start or the end of a phase of the run in the .phases file of a
+# results directory, for junit_parser to report the timeline of the run.
+# Recording never fails the run.
+#
+# Arguments:
+#   $1: start or end
+#   $2: name of the phase, e.g. tests or namespace-cleanup
+#   $3: results directory, defaults to the suite's ${ARTIFACTS}
+#
+tests::phase() {
+  echo "$(date -u +'%Y-%m-%dT%H:%M:%S.%3NZ') $1 $2" >> "${3:-${ARTIFACTS}}/.phases" 2>/dev/null || true
+}
+
 create_kubeconfig() {
   SERVICE_ACCOUNT_DIR=/var/run/secrets/kubernetes.io/serviceaccount
   TOKEN=$(cat ${SERVICE_ACCOUNT_DIR}/token)
@@ -22,19 +22,24 @@ function apply_disk_images_provider() {
     fi
     
     echo "Applying disk-images-provider with KUBEVIRT_RELEASE=${KUBEVIRT_RELEASE}"
+    tests::phase start disk-images-provider
     
     local sed_args=(-e "s|__KUBEVIRT_RELEASE__|${KUBEVIRT_RELEASE}|g")
-    sed "${sed_args[@]}" "${yaml_file}" | oc apply -f -
-    
-    if [ $? -eq 0 ]; then
+    if sed "${sed_args[@]}" "${yaml_file}" | oc apply -f -; then
         DISK_IMAGES_PROVIDER_APPLIED=true
         echo "disk-images-provider applied successfully"
         
         # Wait for the DaemonSet to be ready
         echo "Waiting for disk-images-provider DaemonSet to be ready..."
-        oc rollout status daemonset/disks-images-provider -n openshift-cnv --timeout=300s
+        if ! oc rollout status daemonset/disks-images-provider -n openshift-cnv --timeout=300s; then
+            echo "disk-images-provider DaemonSet did not become ready"
+            tests::phase end disk-images-provider
+            return 1
+        fi
+        tests::phase end disk-images-provider
     else
         echo "Failed to apply disk-images-provider"
+        tests::phase end disk-images-provider
         return 1
     fi
 }
@@ -42,6 +47,7 @@ function apply_disk_images_provider() {
 function cleanup_disk_images_provider() {
     if [ "${DISK_IMAGES_PROVIDER_APPLIED}" = "true" ]; then
         echo "Cleaning up disk-images-provider resources..."
+        tests::phase start disk-images-provider-cleanup
         local yaml_file="${SCRIPT_DIR}/testing-infra/disk-images-provider.yaml"
         if [ -f "${yaml_file}" ]; then
             # Use the same substitution and delete
@@ -49,18 +55,21 @@ function cleanup_disk_images_provider() {
             sed "${sed_args[@]}" "${yaml_file}" | oc delete -f - --ignore-not-found=true
             echo "disk-images-provider resources deleted"
         fi
+        tests::phase end disk-images-provider-cleanup
         DISK_IMAGES_PROVIDER_APPLIED=false
     fi
 }
 
 function cleanup_test_namespaces() {
     echo "Cleaning up test namespaces..."
+    tests::phase start namespace-cleanup
     for ns in kubevirt-test-alternative1 kubevirt-test-default1 kubevirt-test-operator1 kubevirt-test-privileged1; do
         if oc get namespace "$ns" &>/dev/null; then
             echo "Deleting namespace: $ns"
             oc delete namespace "$ns" --ignore-not-found=true
         fi
     done
+    tests::phase end namespace-cleanup
     echo "Test namespaces deletion initiated"
 }
 
@@ -84,6 +93,8 @@ function cleanup_and_exit() {
 readonly SCRIPT_DIR=$(dirname "$(readlink -f "${BASH_SOURCE[0]}")")
 readonly TARGET_NAMESPACE="openshift-cnv"
 
+source "${SCRIPT_DIR}/../funcs.sh"
+
 # Set up signal traps for cleanup EARLY
 trap cleanup_and_exit SIGINT SIGTERM
 
@@ -138,6 +149,7 @@ skip_arg=$(printf -- '--ginkgo.skip=%s' "${skip_regex:0:-1}")
 TESTS_BINARY="kubevirt.test"
 export ARTIFACTS=${RESULTS_DIR}/${SIG}
 mkdir -p "${ARTIFACTS}"
+tests::phase start setup
 
 GINKGO_FLAKE="--ginkgo.flake-attempts=3"
 GINKGO_SLOW="--ginkgo.poll-progress-after=60s"
@@ -213,6 +225,7 @@ if [ -f "${STORAGE_CONFIG_PATH}" ]; then
 fi
 
 label_filter_str="--ginkgo.label-filter=${label_filter_joined}"
+tests::phase end setup
 
 # Apply disk-images-provider if running storage tests (but not in dry-run mode)
 if [ "${SIG}" == "storage" ] && [ -z "${DRY_RUN_FLAG}" ]; then
@@ -220,11 +233,13 @@ if [ "${SIG}" == "storage" ] && [ -z "${DRY_RUN_FLAG}" ]; then
 fi
 
 echo "Starting ${SIG} tests 🧪"
+tests::phase start tests
 (set +e; ${TESTS_BINARY} \
     -cdi-namespace="$TARGET_NAMESPACE" \
     -config="${STORAGE_CONFIG_PATH}" \
     -installed-namespace="$TARGET_NAMESPACE" \
     -junit-output="${ARTIFACTS}/junit.results.xml" \
+    --ginkgo.json-report="${ARTIFACTS}/ginkgo.report.json" \
     "${label_filter_str}" \
     ${ginkgo_focus} \
     ${GINKGO_SLOW} \
@@ -247,6 +262,7 @@ echo "Test process started with PID: ${TEST_PID}"
 
 # Wait for the test to complete
 wait ${TEST_PID}
+tests::phase end tests
 
 # Cleanup disk-images-provider resources if they were applied
 cleanup_disk_images_provider
@@ -42,7 +42,15 @@ skip_arg=$(printf -- '--ginkgo.skip=%s' "${skip_regex:0:-1}")
 SSP_TESTS_BINARY="ssp.test"
 
 source "${SCRIPT_DIR}/../funcs.sh"
-trap tests::hco::enable EXIT INT TERM
+
+function enable_hco() {
+  tests::phase start hco-enable
+  tests::hco::enable
+  tests::phase end hco-enable
+}
+trap enable_hco EXIT INT TERM
+
+tests::phase start setup
 
 # SSP configuration
 export SSP_DEPLOYMENT_NAME='ssp-operator'
@@ -69,15 +77,21 @@ export \
 if [ "${FULL_SUITE}" == "true" ]
 then
   label_filter=""
+  tests::phase end setup
+  tests::phase start hco-disable
   tests::hco::disable
+  tests::phase end hco-disable
 else
   label_filter="--ginkgo.label-filter=conformance"
   export SKIP_UPDATE_SSP_TESTS=true
+  tests::phase end setup
 fi
 
 echo "Starting SSP tests 🧪"
-${SSP_TESTS_BINARY} \
+tests::phase start tests
+(set +e; ${SSP_TESTS_BINARY} \
   --ginkgo.junit-report="${ARTIFACTS}/junit.results.xml" \
+  --ginkgo.json-report="${ARTIFACTS}/ginkgo.report.json" \
   --ginkgo.skip='\[QUARANTINE\]' \
   ${label_filter} \
   ${ginkgo_focus} \
@@ -85,4 +99,5 @@ ${SSP_TESTS_BINARY} \
   --ginkgo.no-color \
   ${DRY_RUN_FLAG} \
   --ginkgo.timeout='2h' \
-  "${skip_arg}" 2>&1 | tee ${ARTIFACTS}/ssp-log.txt
+  "${skip_arg}"; echo $? > "${ARTIFACTS}/.exit_code") 2>&1 | tee ${ARTIFACTS}/ssp-log.txt
+tests::phase end tests
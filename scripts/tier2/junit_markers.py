@@ -0,0 +1,29 @@
+"""pytest plugin recording the tags of the tier2 tests as JUnit properties.
+
+junit_parser weighs the tests of the readiness score by their crit and level
+tags, and identifies them by their test_id; pytest only writes the markers of
+a test to the JUnit XML when they are recorded as properties of the test.
+"""
+
+# Markers whose first argument is recorded under the same property name.
+TAG_MARKERS = ("test_id", "rfe_id", "crit", "level")
+
+# Prefix of the Polarion test case IDs of the polarion marker, e.g. "CNV-1783".
+POLARION_ID_PREFIX = "CNV-"
+
+
+def marker_properties(item):
+    properties = []
+    for marker in item.iter_markers(name="polarion"):
+        for test_case in marker.args:
+            properties.append(("test_id", str(test_case).removeprefix(POLARION_ID_PREFIX)))
+    for name in TAG_MARKERS:
+        marker = item.get_closest_marker(name)
+        if marker is not None and marker.args:
+            properties.append((name, str(marker.args[0])))
+    return properties
+
+
+def pytest_collection_modifyitems(items):
+    for item in items:
+        item.user_properties.extend(marker_properties(item))
@@ -4,12 +4,14 @@ set -e
 
 function cleanup_test_namespaces() {
     echo "Cleaning up tier2 test namespaces..."
+    tests::phase start namespace-cleanup
     for ns in cnv-tests-run-in-progress-ns cnv-tests-utilities; do
         if oc get namespace "$ns" &>/dev/null; then
             echo "Deleting namespace: $ns"
             oc delete namespace "$ns" --ignore-not-found=true
         fi
     done
+    tests::phase end namespace-cleanup
     echo "Tier2 test namespaces deletion initiated"
 }
 
@@ -64,6 +66,9 @@ function wait_for_pytest_pipeline() {
     wait "${pid}" 2>/dev/null || true
 }
 
+readonly SCRIPT_DIR=$(dirname "$(readlink -f "${BASH_SOURCE[0]}")")
+source "${SCRIPT_DIR}/../funcs.sh"
+
 # Set up signal traps for cleanup EARLY
 trap cleanup_and_exit SIGINT SIGTERM
 
@@ -71,6 +76,7 @@ cd /openshift-virtualization-tests
 
 export ARTIFACTS=${RESULTS_DIR}/tier2
 mkdir -p "${ARTIFACTS}"
+tests::phase start setup
 
 
 SUBSCRIPTION_NAME=$(oc get subs -n openshift-cnv -l operators.coreos.com/kubevirt-hyperconverged.openshift-cnv= -o json | jq -r '.items[0].metadata.name')
@@ -246,8 +252,10 @@ if [ "${ACCEPT_WINDOWS_EULA}" == "true" ]; then
   WIN_IMAGE_FLAG="--tc=win_golden_image_name:${WIN_IMAGE_NAME:-windows2022-golden-image} --tc=os_login_param.win.username:${WIN_USERNAME:-Administrator} --tc=os_login_param.win.password:${WIN_PASSWORD:-Heslo123} --tc=storage_class_a:${STORAGE_CLASS} --tc=storage_class_b:${STORAGE_CLASS}"
 fi
 
+tests::phase end setup
 echo "Starting tier2 tests 🧪"
 echo "Using markers: ${MARKERS}"
+tests::phase start tests
 
 if [ "${DRY_RUN}" == "true" ]; then
   # In dry-run mode, collect tests and generate a proper JUnit XML with all
@@ -348,7 +356,11 @@ print(f'Generated JUnit XML with {total_tests} test(s) ({len(test_ids)} collecte
 
 else
   rm -f "${ARTIFACTS}/.exit_code"
-  (set +e; .venv/bin/pytest \
+  # The junit_markers plugin records the test_id, crit and level markers of the
+  # tests as properties in the JUnit XML, which the xunit1 family keeps per test.
+  (set +e; PYTHONPATH="${SCRIPT_DIR}${PYTHONPATH:+:${PYTHONPATH}}" .venv/bin/pytest \
+    -p junit_markers \
+    -o junit_family=xunit1 \
     -m "${MARKERS}" \
     -W "ignore::pytest.PytestRemovedIn10Warning" \
     --skip-artifactory-check \
@@ -451,3 +463,4 @@ if matched:
 " "${ARTIFACTS}/junit.results.xml" "${TEST_SKIPS}" "${TEST_FOCUS}"
   fi
 fi
+tests::phase end tests